@@ -0,0 +1,10 @@
+data "opsecret_credit_card" "test_card" {
+  vault = "test-fixtures"
+  item  = "test-visa"
+}
+
+resource "whatever" "payment_test" {
+  card_number = data.opsecret_credit_card.test_card.number
+  card_expiry = data.opsecret_credit_card.test_card.expiry
+  card_cvv    = data.opsecret_credit_card.test_card.cvv
+}
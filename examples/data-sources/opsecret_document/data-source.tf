@@ -0,0 +1,9 @@
+data "opsecret_document" "tls_certificate" {
+  vault    = "vault-name"
+  item     = "tls-certificate"
+  encoding = "raw"
+}
+
+resource "whatever" "some_resource" {
+  certificate = data.opsecret_document.tls_certificate.content
+}
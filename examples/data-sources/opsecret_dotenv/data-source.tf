@@ -0,0 +1,11 @@
+data "opsecret_dotenv" "app" {
+  references = {
+    DATABASE_USER     = "op://vault-name/database/username"
+    DATABASE_PASSWORD = "op://vault-name/database/password"
+  }
+}
+
+resource "local_sensitive_file" "env" {
+  filename = "${path.module}/.env"
+  content  = data.opsecret_dotenv.app.content
+}
@@ -0,0 +1,10 @@
+data "opsecret_field" "admin_password" {
+  vault   = "vault-name"
+  item    = "database"
+  section = "admin"
+  field   = "password"
+}
+
+resource "whatever" "some_resource" {
+  password = data.opsecret_field.admin_password.value
+}
@@ -0,0 +1,10 @@
+data "opsecret_identity" "test_user" {
+  vault = "test-fixtures"
+  item  = "test-user"
+}
+
+resource "whatever" "signup_test" {
+  first_name = data.opsecret_identity.test_user.first_name
+  last_name  = data.opsecret_identity.test_user.last_name
+  email      = data.opsecret_identity.test_user.email
+}
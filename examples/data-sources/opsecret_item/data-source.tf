@@ -0,0 +1,21 @@
+data "opsecret_item" "database" {
+  vault = "vault-name"
+  item  = "item-name"
+}
+
+resource "whatever" "some_resource" {
+  username = data.opsecret_item.database.fields["username"]
+  password = data.opsecret_item.database.fields["password"]
+}
+
+data "opsecret_item" "database_environment" {
+  vault   = "vault-name"
+  item    = "item-name"
+  pattern = "DB_*"
+}
+
+# read the item from the vault of the environment, falling back to the shared vault
+data "opsecret_item" "api_client" {
+  vaults = ["app-${var.environment}", "app-shared"]
+  item   = "api-client"
+}
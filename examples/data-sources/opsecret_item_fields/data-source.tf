@@ -0,0 +1,8 @@
+data "opsecret_item_fields" "legacy" {
+  vault = "vault-name"
+  item  = "legacy-credentials"
+}
+
+output "legacy_field_labels" {
+  value = [for field in data.opsecret_item_fields.legacy.fields : "${coalesce(field.section, "-")}/${field.label} (${field.type})"]
+}
@@ -0,0 +1,11 @@
+data "opsecret_item_metadata" "database" {
+  vault = "vault-name"
+  item  = "database"
+}
+
+check "database_password_rotation" {
+  assert {
+    condition     = timecmp(timeadd(data.opsecret_item_metadata.database.updated_at, "2160h"), timestamp()) > 0
+    error_message = "The database password has not been rotated for 90 days."
+  }
+}
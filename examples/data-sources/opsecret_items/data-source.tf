@@ -0,0 +1,9 @@
+data "opsecret_items" "items" {
+  vault = "vault-name"
+}
+
+data "opsecret_secret_reference" "passwords" {
+  for_each = { for item in data.opsecret_items.items.items : item.title => item if item.category == "Password" }
+
+  id = "op://vault-name/${each.value.id}/password"
+}
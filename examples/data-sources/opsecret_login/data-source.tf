@@ -0,0 +1,10 @@
+data "opsecret_login" "database" {
+  vault = "vault-name"
+  item  = "database"
+}
+
+resource "whatever" "some_resource" {
+  url      = data.opsecret_login.database.urls[0]
+  username = data.opsecret_login.database.username
+  password = data.opsecret_login.database.password
+}
@@ -0,0 +1,9 @@
+data "opsecret_note" "ssh_config" {
+  vault = "vault-name"
+  item  = "ssh-config"
+}
+
+resource "local_sensitive_file" "ssh_config" {
+  filename = "${path.module}/ssh_config"
+  content  = data.opsecret_note.ssh_config.content
+}
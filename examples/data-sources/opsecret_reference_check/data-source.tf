@@ -0,0 +1,10 @@
+data "opsecret_reference_check" "database_password" {
+  id = "op://vault-name/database/password"
+
+  lifecycle {
+    postcondition {
+      condition     = self.exists
+      error_message = "The database password is ${self.status}: ${coalesce(self.detail, "")}"
+    }
+  }
+}
@@ -4,4 +4,13 @@ data "opsecret_reference" "secret_reference" {
 
 resource "whatever" "some_resource" {
   attribute = data.opsecret_reference.secret_reference.value
-}
\ No newline at end of file
+}
+
+data "opsecret_secret_reference" "app_config" {
+  id         = "op://vault-name/item-name/config.json"
+  parse_json = true
+}
+
+resource "whatever" "configured_resource" {
+  endpoint = data.opsecret_secret_reference.app_config.json.endpoint
+}
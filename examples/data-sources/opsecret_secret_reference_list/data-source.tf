@@ -0,0 +1,16 @@
+variable "secret_refs" {
+  type = list(string)
+  default = [
+    "op://vault-name/api-a/credential",
+    "op://vault-name/api-b/credential",
+  ]
+}
+
+data "opsecret_secret_reference_list" "api_keys" {
+  references = var.secret_refs
+}
+
+resource "whatever" "some_resource" {
+  count   = length(var.secret_refs)
+  api_key = data.opsecret_secret_reference_list.api_keys.values[count.index]
+}
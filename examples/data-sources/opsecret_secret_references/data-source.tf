@@ -0,0 +1,11 @@
+data "opsecret_secret_references" "database" {
+  references = {
+    username = "op://vault-name/item-name/username"
+    password = "op://vault-name/item-name/password"
+  }
+}
+
+resource "whatever" "some_resource" {
+  username = data.opsecret_secret_references.database.values["username"]
+  password = data.opsecret_secret_references.database.values["password"]
+}
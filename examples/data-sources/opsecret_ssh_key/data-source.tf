@@ -0,0 +1,10 @@
+data "opsecret_ssh_key" "deploy_key" {
+  vault              = "vault-name"
+  item               = "deploy-key"
+  private_key_format = "openssh"
+}
+
+resource "whatever" "some_resource" {
+  private_key = data.opsecret_ssh_key.deploy_key.private_key
+  public_key  = data.opsecret_ssh_key.deploy_key.public_key
+}
@@ -0,0 +1,8 @@
+data "opsecret_status" "preflight" {
+  lifecycle {
+    postcondition {
+      condition     = self.ok
+      error_message = "Cannot access 1Password: ${coalesce(self.detail, "unknown error")}"
+    }
+  }
+}
@@ -0,0 +1,10 @@
+# reads the password of the most recently updated item tagged as active
+data "opsecret_tagged_item" "database" {
+  vault = "vault-name"
+  tag   = "active"
+  field = "password"
+}
+
+resource "whatever" "some_resource" {
+  password = data.opsecret_tagged_item.database.value
+}
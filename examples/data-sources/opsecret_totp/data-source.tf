@@ -0,0 +1,8 @@
+data "opsecret_totp" "login" {
+  vault = "vault-name"
+  item  = "item-name"
+}
+
+resource "whatever" "some_resource" {
+  otp = data.opsecret_totp.login.code
+}
@@ -0,0 +1,7 @@
+data "opsecret_vault" "infrastructure" {
+  title = "infrastructure"
+}
+
+data "opsecret_items" "infrastructure" {
+  vault = data.opsecret_vault.infrastructure.id
+}
@@ -0,0 +1,5 @@
+data "opsecret_vaults" "all" {}
+
+output "vault_titles" {
+  value = [for vault in data.opsecret_vaults.all.vaults : vault.title]
+}
@@ -0,0 +1,7 @@
+ephemeral "opsecret_secret_reference" "db_password" {
+  id = "op://vault-name/item-name/section-name/field-name"
+}
+
+provider "postgresql" {
+  password = ephemeral.opsecret_secret_reference.db_password.value
+}
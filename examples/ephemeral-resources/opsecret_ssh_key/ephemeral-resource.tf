@@ -0,0 +1,9 @@
+ephemeral "opsecret_ssh_key" "deploy_key" {
+  vault = "vault-name"
+  item  = "deploy-key"
+}
+
+resource "whatever" "some_resource" {
+  # ephemeral values can only be passed to provider configurations and write-only attributes
+  private_key_wo = ephemeral.opsecret_ssh_key.deploy_key.private_key
+}
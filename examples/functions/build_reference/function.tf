@@ -0,0 +1,8 @@
+data "opsecret_secret_reference" "password" {
+  # results in op://CI%20%2F%20CD%20Secrets/database/password
+  id = provider::opsecret::build_reference("CI / CD Secrets", "database", "password")
+}
+
+data "opsecret_secret_reference" "section_password" {
+  id = provider::opsecret::build_reference("CI / CD Secrets", "database", "admin", "password")
+}
@@ -0,0 +1,4 @@
+resource "local_sensitive_file" "certificate" {
+  filename = "${path.module}/certificate.pem"
+  content  = provider::opsecret::decode_file("op://vault-name/item-name/certificate.pem")
+}
@@ -0,0 +1,7 @@
+locals {
+  reference = provider::opsecret::parse_reference("op://vault-name/item-name/section-name/field-name")
+}
+
+output "item" {
+  value = local.reference.item
+}
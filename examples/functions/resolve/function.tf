@@ -0,0 +1,3 @@
+resource "whatever" "some_resource" {
+  attribute = provider::opsecret::resolve("op://vault-name/item-name/section-name/field-name")
+}
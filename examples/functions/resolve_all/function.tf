@@ -0,0 +1,10 @@
+locals {
+  environment = provider::opsecret::resolve_all({
+    DATABASE_PASSWORD = "op://vault-name/database/password"
+    API_TOKEN         = "op://vault-name/api/credential"
+  })
+}
+
+resource "whatever" "some_resource" {
+  environment = local.environment
+}
@@ -0,0 +1,5 @@
+resource "null_resource" "login" {
+  provisioner "local-exec" {
+    command = "login --otp ${provider::opsecret::totp("op://vault-name/item-name/one-time password")}"
+  }
+}
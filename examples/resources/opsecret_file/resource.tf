@@ -0,0 +1,14 @@
+resource "opsecret_file" "service_config" {
+  vault     = "vault-name"
+  item      = "item-name"
+  file_name = "config.yaml"
+  source    = "${path.module}/config.yaml"
+}
+
+resource "opsecret_file" "keystore" {
+  vault          = "vault-name"
+  item           = "item-name"
+  section        = "Certificates"
+  file_name      = "keystore.p12"
+  content_base64 = filebase64("${path.module}/keystore.p12")
+}
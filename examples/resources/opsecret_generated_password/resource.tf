@@ -0,0 +1,14 @@
+resource "opsecret_generated_password" "database" {
+  vault   = "vault-name"
+  title   = "database-password"
+  length  = 40
+  symbols = false
+
+  keepers = {
+    database = "orders"
+  }
+}
+
+resource "whatever" "some_resource" {
+  password = opsecret_generated_password.database.password
+}
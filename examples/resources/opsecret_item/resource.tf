@@ -0,0 +1,17 @@
+resource "opsecret_item" "api_client" {
+  vault    = "vault-name"
+  title    = "api-client"
+  category = "ApiCredentials"
+
+  fields = [
+    {
+      label = "client_id"
+      value = "my-client"
+    },
+    {
+      label = "client_secret"
+      type  = "Concealed"
+      value = random_password.client_secret.result
+    },
+  ]
+}
@@ -0,0 +1,9 @@
+resource "opsecret_item_field" "deploy_token" {
+  vault = "vault-name"
+  item  = "ci-credentials"
+  field = "deploy_token"
+  value = gitlab_deploy_token.ci.token
+
+  # empty the field when the token is no longer managed by terraform
+  on_destroy = "clear"
+}
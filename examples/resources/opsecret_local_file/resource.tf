@@ -0,0 +1,5 @@
+resource "opsecret_local_file" "keystore" {
+  reference       = "op://vault-name/item-name/keystore.p12"
+  destination     = "${path.module}/secrets/keystore.p12"
+  file_permission = "0600"
+}
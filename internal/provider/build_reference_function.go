@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &buildReferenceFunction{}
+
+func NewBuildReferenceFunction() function.Function {
+	return &buildReferenceFunction{}
+}
+
+type buildReferenceFunction struct{}
+
+func (f *buildReferenceFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_reference"
+}
+
+func (f *buildReferenceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a 1Password secret reference from its parts",
+		MarkdownDescription: "Builds a 1Password secret reference from the given vault, item and either field or section and field, " +
+			"percent-encoding each part so titles containing spaces or slashes can be resolved.<br>" +
+			"This is the inverse of the `parse_reference` function.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "vault",
+				MarkdownDescription: "The title or ID of the vault.",
+			},
+			function.StringParameter{
+				Name:                "item",
+				MarkdownDescription: "The title or ID of the item.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "path",
+			MarkdownDescription: "Either the field, or the section followed by the field. If omitted, the reference points to the primary value of the item.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildReferenceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vault, item string
+	var pathElements []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &vault, &item, &pathElements))
+	if resp.Error != nil {
+		return
+	}
+
+	reference := secretReference{vault: vault, item: item}
+	switch len(pathElements) {
+	case 0:
+		// references without a field point to the primary value of the item
+	case 1:
+		reference.field = pathElements[0]
+	case 2:
+		reference.section = pathElements[0]
+		reference.field = pathElements[1]
+	default:
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Either no field, a field or a section and a field must be given, but got %d values", len(pathElements)))
+		return
+	}
+
+	// parsing the built reference again ensures it can be resolved, e.g. that no part is empty
+	if _, err := parseSecretReference(reference.String()); err != nil {
+		resp.Error = function.NewFuncError("Unable to build secret reference: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, reference.String()))
+}
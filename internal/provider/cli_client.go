@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// errCliUnsupported is returned for operations the 1Password CLI client does not support.
+var errCliUnsupported = errors.New("operation is not supported when using the 1Password CLI")
+
+// cliCommand is the name of the 1Password CLI executable looked up on the PATH.
+const cliCommand = "op"
+
+// newCliClient creates a onepassword client running the locally installed and signed in 1Password CLI,
+// e.g. for developers planning locally without a service account token.
+// Only read operations are supported by the returned client. The CLI selects the account itself, e.g. by the OP_ACCOUNT environment variable.
+func newCliClient() (*onepassword.Client, error) {
+	executable, err := exec.LookPath(cliCommand)
+	if err != nil {
+		return nil, fmt.Errorf("the 1Password CLI '%s' is not installed or not on the PATH: %w", cliCommand, err)
+	}
+	cli := &cliClient{executable: executable}
+	return &onepassword.Client{
+		SecretsAPI: &cliSecrets{cli},
+		ItemsAPI:   &cliItems{cli},
+		VaultsAPI:  &cliVaults{cli},
+	}, nil
+}
+
+// cliClient runs commands of the 1Password CLI.
+type cliClient struct {
+	executable string
+}
+
+// cliVault is a vault as listed by the CLI, which uses snake case timestamps unlike the Connect API.
+type cliVault struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// cliItem is an item as returned by the CLI, which matches the items of the Connect API apart from the snake case timestamps.
+type cliItem struct {
+	connectItem
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// toItem converts the given CLI item to its SDK counterpart.
+func (i cliItem) toItem() onepassword.Item {
+	item := i.connectItem
+	item.CreatedAt = i.CreatedAt
+	item.UpdatedAt = i.UpdatedAt
+	return item.toItem()
+}
+
+// run runs the CLI with the given arguments, returning its standard output.
+// Failures are reported with the error message the CLI printed, e.g. if it is not signed in.
+func (c *cliClient) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.executable, args...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("1Password CLI failed: %s", message)
+	}
+	return output, nil
+}
+
+// runJSON runs the CLI with the given arguments, decoding its JSON output into result.
+func (c *cliClient) runJSON(ctx context.Context, result any, args ...string) error {
+	output, err := c.run(ctx, append(args, "--format", "json")...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(output, result)
+}
+
+// cliVaults implements the vaults API of the SDK using the CLI.
+type cliVaults struct {
+	*cliClient
+}
+
+func (v *cliVaults) List(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	var vaults []cliVault
+	if err := v.runJSON(ctx, &vaults, "vault", "list"); err != nil {
+		return nil, err
+	}
+	result := []onepassword.VaultOverview{}
+	for _, vault := range vaults {
+		result = append(result, onepassword.VaultOverview{
+			ID:        vault.ID,
+			Title:     vault.Name,
+			CreatedAt: vault.CreatedAt,
+			UpdatedAt: vault.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// cliItems implements the items API of the SDK using the CLI.
+type cliItems struct {
+	*cliClient
+}
+
+func (i *cliItems) Create(context.Context, onepassword.ItemCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errCliUnsupported
+}
+
+func (i *cliItems) Get(ctx context.Context, vaultID string, itemID string) (onepassword.Item, error) {
+	var item cliItem
+	if err := i.runJSON(ctx, &item, "item", "get", itemID, "--vault", vaultID); err != nil {
+		return onepassword.Item{}, err
+	}
+	return item.toItem(), nil
+}
+
+func (i *cliItems) Put(context.Context, onepassword.Item) (onepassword.Item, error) {
+	return onepassword.Item{}, errCliUnsupported
+}
+
+func (i *cliItems) Delete(context.Context, string, string) error {
+	return errCliUnsupported
+}
+
+func (i *cliItems) Archive(context.Context, string, string) error {
+	return errCliUnsupported
+}
+
+func (i *cliItems) List(ctx context.Context, vaultID string, _ ...onepassword.ItemListFilter) ([]onepassword.ItemOverview, error) {
+	var items []cliItem
+	if err := i.runJSON(ctx, &items, "item", "list", "--vault", vaultID); err != nil {
+		return nil, err
+	}
+	result := []onepassword.ItemOverview{}
+	for _, listed := range items {
+		item := listed.toItem()
+		result = append(result, onepassword.ItemOverview{
+			ID:        item.ID,
+			Title:     item.Title,
+			Category:  item.Category,
+			VaultID:   item.VaultID,
+			Websites:  item.Websites,
+			Tags:      item.Tags,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			State:     onepassword.ItemStateActive,
+		})
+	}
+	return result, nil
+}
+
+func (i *cliItems) Shares() onepassword.ItemsSharesAPI {
+	return &cliItemsShares{}
+}
+
+func (i *cliItems) Files() onepassword.ItemsFilesAPI {
+	return &cliItemsFiles{i.cliClient}
+}
+
+// cliItemsFiles implements the item files API of the SDK using the CLI.
+type cliItemsFiles struct {
+	*cliClient
+}
+
+func (f *cliItemsFiles) Attach(context.Context, onepassword.Item, onepassword.FileCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errCliUnsupported
+}
+
+// Read reads the content of the file by a secret reference made of IDs, which the CLI writes to the standard output as it is.
+func (f *cliItemsFiles) Read(ctx context.Context, vaultID string, itemID string, attr onepassword.FileAttributes) ([]byte, error) {
+	return f.run(ctx, "read", secretReference{vault: vaultID, item: itemID, field: attr.ID}.sdkString())
+}
+
+func (f *cliItemsFiles) Delete(context.Context, onepassword.Item, string, string) (onepassword.Item, error) {
+	return onepassword.Item{}, errCliUnsupported
+}
+
+func (f *cliItemsFiles) ReplaceDocument(context.Context, onepassword.Item, onepassword.DocumentCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errCliUnsupported
+}
+
+// cliItemsShares implements the item shares API of the SDK, which is not supported using the CLI.
+type cliItemsShares struct{}
+
+func (s *cliItemsShares) GetAccountPolicy(context.Context, string, string) (onepassword.ItemShareAccountPolicy, error) {
+	return onepassword.ItemShareAccountPolicy{}, errCliUnsupported
+}
+
+func (s *cliItemsShares) ValidateRecipients(context.Context, onepassword.ItemShareAccountPolicy, []string) ([]onepassword.ValidRecipient, error) {
+	return nil, errCliUnsupported
+}
+
+func (s *cliItemsShares) Create(context.Context, onepassword.Item, onepassword.ItemShareAccountPolicy, onepassword.ItemShareParams) (string, error) {
+	return "", errCliUnsupported
+}
+
+// cliSecrets implements the secrets API of the SDK using the CLI, which resolves secret references itself.
+type cliSecrets struct {
+	*cliClient
+}
+
+func (s *cliSecrets) Resolve(ctx context.Context, secretReference string) (string, error) {
+	value, err := s.run(ctx, "read", "--no-newline", secretReference)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (s *cliSecrets) ResolveAll(context.Context, []string) (onepassword.ResolveAllResponse, error) {
+	return onepassword.ResolveAllResponse{}, errCliUnsupported
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// cliItemOutput is recorded output of `op item get --format json`.
+const cliItemOutput = `{
+  "id": "database000000000000000000",
+  "title": "Database",
+  "version": 3,
+  "vault": {"id": "shared00000000000000000000", "name": "Shared"},
+  "category": "DATABASE",
+  "last_edited_by": "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+  "created_at": "2024-05-02T09:15:00Z",
+  "updated_at": "2025-01-20T17:30:12Z",
+  "tags": ["prod"],
+  "urls": [{"label": "console", "primary": true, "href": "https://db.example.com"}],
+  "sections": [{"id": "tls00000000000000000000000", "label": "TLS"}],
+  "fields": [
+    {"id": "notesPlain", "type": "STRING", "purpose": "NOTES", "label": "notesPlain", "value": "rotated yearly", "reference": "op://Shared/Database/notesPlain"},
+    {"id": "password", "type": "CONCEALED", "purpose": "PASSWORD", "label": "password", "value": "secret-password", "reference": "op://Shared/Database/password"},
+    {"id": "ca", "section": {"id": "tls00000000000000000000000", "label": "TLS"}, "type": "STRING", "label": "ca", "value": "root", "reference": "op://Shared/Database/TLS/ca"}
+  ],
+  "files": [
+    {"id": "cert0000000000000000000000", "name": "cert.pem", "size": 4, "content_path": "/v1/vaults/shared00000000000000000000/items/database000000000000000000/files/cert0000000000000000000000/content", "section": {"id": "tls00000000000000000000000", "label": "TLS"}}
+  ]
+}`
+
+// cliVaultsOutput is recorded output of `op vault list --format json`.
+const cliVaultsOutput = `[
+  {"id": "shared00000000000000000000", "name": "Shared", "content_version": 42, "created_at": "2023-11-08T10:00:00Z", "updated_at": "2025-02-01T08:45:30Z", "items": 12}
+]`
+
+// cliSignedOutError is recorded standard error output of the CLI when it is not signed in.
+const cliSignedOutError = "[ERROR] 2025/02/03 10:11:12 You are not currently signed in. Please run `op signin --help` for instructions"
+
+// newStubCliClient returns a client running a stub of the CLI on the PATH, which prints the recorded output
+// for `item get` and `vault list` and fails like a signed out CLI otherwise.
+func newStubCliClient(t *testing.T) *onepassword.Client {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the CLI stub is a shell script")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"item.json":   cliItemOutput,
+		"vaults.json": cliVaultsOutput,
+		"error.txt":   cliSignedOutError,
+		cliCommand: `#!/bin/sh
+case "$1 $2" in
+"item get") cat "` + dir + `/item.json" ;;
+"vault list") cat "` + dir + `/vaults.json" ;;
+*) cat "` + dir + `/error.txt" >&2; exit 1 ;;
+esac
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	client, err := newCliClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestCliItemsGet(t *testing.T) {
+	client := newStubCliClient(t)
+
+	item, err := client.Items().Get(context.Background(), testId("shared"), testId("database"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.ID != testId("database") || item.Title != "Database" || item.VaultID != testId("shared") || item.Category != onepassword.ItemCategoryDatabase {
+		t.Errorf("Get returned the item %q (%s) with ID %q in the vault %q, want the database item", item.Title, item.Category, item.ID, item.VaultID)
+	}
+	if want := time.Date(2024, 5, 2, 9, 15, 0, 0, time.UTC); !item.CreatedAt.Equal(want) {
+		t.Errorf("Get returned the creation time %v, want %v", item.CreatedAt, want)
+	}
+	if want := time.Date(2025, 1, 20, 17, 30, 12, 0, time.UTC); !item.UpdatedAt.Equal(want) {
+		t.Errorf("Get returned the update time %v, want %v", item.UpdatedAt, want)
+	}
+	if item.Notes != "rotated yearly" {
+		t.Errorf("Get returned the notes %q, want %q", item.Notes, "rotated yearly")
+	}
+	if len(item.Websites) != 1 || item.Websites[0].URL != "https://db.example.com" {
+		t.Errorf("Get returned the websites %+v, want the console URL", item.Websites)
+	}
+	if len(item.Sections) != 1 || item.Sections[0].Title != "TLS" {
+		t.Errorf("Get returned the sections %+v, want the TLS section", item.Sections)
+	}
+	if len(item.Fields) != 2 {
+		t.Fatalf("Get returned the fields %+v, want the password and ca fields", item.Fields)
+	}
+	if password := item.Fields[0]; password.Title != "password" || password.FieldType != onepassword.ItemFieldTypeConcealed || password.Value != "secret-password" {
+		t.Errorf("Get returned the field %+v, want the concealed password", password)
+	}
+	if ca := item.Fields[1]; ca.SectionID == nil || *ca.SectionID != testId("tls") {
+		t.Errorf("Get returned the field %+v, want it in the TLS section", ca)
+	}
+	if len(item.Files) != 1 || item.Files[0].Attributes.ID != testId("cert") || item.Files[0].Attributes.Size != 4 || item.Files[0].SectionID != testId("tls") {
+		t.Errorf("Get returned the files %+v, want the certificate in the TLS section", item.Files)
+	}
+}
+
+func TestCliVaultsList(t *testing.T) {
+	client := newStubCliClient(t)
+
+	vaults, err := client.Vaults().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vaults) != 1 {
+		t.Fatalf("List returned the vaults %+v, want one vault", vaults)
+	}
+	vault := vaults[0]
+	if vault.ID != testId("shared") || vault.Title != "Shared" {
+		t.Errorf("List returned the vault %q with ID %q, want the shared vault", vault.Title, vault.ID)
+	}
+	if want := time.Date(2023, 11, 8, 10, 0, 0, 0, time.UTC); !vault.CreatedAt.Equal(want) {
+		t.Errorf("List returned the creation time %v, want %v", vault.CreatedAt, want)
+	}
+	if want := time.Date(2025, 2, 1, 8, 45, 30, 0, time.UTC); !vault.UpdatedAt.Equal(want) {
+		t.Errorf("List returned the update time %v, want %v", vault.UpdatedAt, want)
+	}
+}
+
+func TestCliReportsErrorOutput(t *testing.T) {
+	client := newStubCliClient(t)
+
+	_, err := client.Items().List(context.Background(), testId("shared"))
+	if err == nil {
+		t.Fatal("List succeeded, want the error of the CLI")
+	}
+	if want := "1Password CLI failed: " + cliSignedOutError; err.Error() != want {
+		t.Errorf("List returned the error %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+)
+
+// serviceAccountTokenPrefix is the prefix of all 1Password service account tokens.
+const serviceAccountTokenPrefix = "ops_"
+
+// tokenRejectedErrorIndicators are lower case message fragments of the SDK for service account tokens 1Password no longer accepts,
+// specific enough not to match messages like "certificate has expired" or titles like "revoked-keys".
+var tokenRejectedErrorIndicators = []string{
+	"you are not authenticated",
+	"bad service account token",
+	"throttle token is invalid",
+}
+
+// clientErrorCategory describes a common cause of failing to create or authenticate a 1Password client,
+// recognized by lower case message fragments of the error, along with a hint on how to fix it.
+type clientErrorCategory struct {
+	summary    string
+	indicators []string
+	hint       string
+}
+
+// clientErrorCategories lists the known causes of client errors, checked in order.
+var clientErrorCategories = []clientErrorCategory{
+	{
+		summary:    "Malformed Service Account Token",
+		indicators: []string{"malformed", "invalid format", "decode", "base64", "invalid character", "unexpected end of json"},
+		hint: "The service account token could not be parsed. Make sure to copy the complete token starting with '" + serviceAccountTokenPrefix + "', " +
+			"without quotes, line breaks or surrounding whitespace.",
+	},
+	{
+		summary:    "Expired or Revoked Service Account Token",
+		indicators: []string{"expired", "revoked", "deleted", "unauthorized", "not authorized", "401", "invalid credentials", "authentication"},
+		hint:       "1Password rejected the service account token. Check in the 1Password admin console whether the service account still exists, and create a new token if the token has expired or has been revoked.",
+	},
+	{
+		summary:    "Unsupported Token",
+		indicators: []string{"unsupported", "not supported"},
+		hint: "The token is not supported by the 1Password SDK. Only service account tokens starting with '" + serviceAccountTokenPrefix + "' can be used as service_account_token, " +
+			"1Password Connect tokens need to be configured as connect_token along with connect_host.",
+	},
+	{
+		summary:    "1Password Unreachable",
+		indicators: []string{"no such host", "dial tcp", "connection refused", "connection reset", "network is unreachable", "i/o timeout", "tls", "certificate", "proxy", "timed out", "eof"},
+		hint:       "1Password could not be reached. Check the network connection and DNS resolution of this machine, and configure proxy_url or the HTTPS_PROXY environment variable if a forward proxy is required.",
+	},
+}
+
+// describes the given error of creating or authenticating a client with the given service account token,
+// returning the summary and the detail of the diagnostic including a hint on how to fix the cause of the error.
+func describeClientError(err error, token string) (string, string) {
+	message := strings.ToLower(err.Error())
+	for _, category := range clientErrorCategories {
+		for _, indicator := range category.indicators {
+			if strings.Contains(message, indicator) {
+				return category.summary, category.hint + "\n\n" + err.Error()
+			}
+		}
+	}
+
+	detail := "The Onepassword API client cannot be created with the given service account token, which may be malformed, invalid or expired."
+	if token != "" && !strings.HasPrefix(token, serviceAccountTokenPrefix) {
+		detail += " Service account tokens start with '" + serviceAccountTokenPrefix + "', but the given token does not."
+	}
+	return "Invalid Service Account Token", detail + "\n\n" + err.Error()
+}
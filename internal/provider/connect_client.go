@@ -0,0 +1,606 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// errConnectUnsupported is returned for operations the 1Password Connect server client does not support.
+var errConnectUnsupported = errors.New("operation is not supported when using a 1Password Connect server")
+
+// connectCategories maps the item categories of the Connect API to the categories of the SDK.
+var connectCategories = map[string]onepassword.ItemCategory{
+	"LOGIN":                  onepassword.ItemCategoryLogin,
+	"SECURE_NOTE":            onepassword.ItemCategorySecureNote,
+	"CREDIT_CARD":            onepassword.ItemCategoryCreditCard,
+	"CRYPTO_WALLET":          onepassword.ItemCategoryCryptoWallet,
+	"IDENTITY":               onepassword.ItemCategoryIdentity,
+	"PASSWORD":               onepassword.ItemCategoryPassword,
+	"DOCUMENT":               onepassword.ItemCategoryDocument,
+	"API_CREDENTIAL":         onepassword.ItemCategoryAPICredentials,
+	"BANK_ACCOUNT":           onepassword.ItemCategoryBankAccount,
+	"DATABASE":               onepassword.ItemCategoryDatabase,
+	"DRIVER_LICENSE":         onepassword.ItemCategoryDriverLicense,
+	"EMAIL_ACCOUNT":          onepassword.ItemCategoryEmail,
+	"MEDICAL_RECORD":         onepassword.ItemCategoryMedicalRecord,
+	"MEMBERSHIP":             onepassword.ItemCategoryMembership,
+	"OUTDOOR_LICENSE":        onepassword.ItemCategoryOutdoorLicense,
+	"PASSPORT":               onepassword.ItemCategoryPassport,
+	"REWARD_PROGRAM":         onepassword.ItemCategoryRewards,
+	"WIRELESS_ROUTER":        onepassword.ItemCategoryRouter,
+	"SERVER":                 onepassword.ItemCategoryServer,
+	"SSH_KEY":                onepassword.ItemCategorySSHKey,
+	"SOCIAL_SECURITY_NUMBER": onepassword.ItemCategorySocialSecurityNumber,
+	"SOFTWARE_LICENSE":       onepassword.ItemCategorySoftwareLicense,
+}
+
+// connectFieldTypes maps the field types of the Connect API to the field types of the SDK.
+var connectFieldTypes = map[string]onepassword.ItemFieldType{
+	"STRING":             onepassword.ItemFieldTypeText,
+	"CONCEALED":          onepassword.ItemFieldTypeConcealed,
+	"CREDIT_CARD_TYPE":   onepassword.ItemFieldTypeCreditCardType,
+	"CREDIT_CARD_NUMBER": onepassword.ItemFieldTypeCreditCardNumber,
+	"PHONE":              onepassword.ItemFieldTypePhone,
+	"URL":                onepassword.ItemFieldTypeURL,
+	"OTP":                onepassword.ItemFieldTypeTOTP,
+	"EMAIL":              onepassword.ItemFieldTypeEmail,
+	"REFERENCE":          onepassword.ItemFieldTypeReference,
+	"SSHKEY":             onepassword.ItemFieldTypeSSHKey,
+	"MENU":               onepassword.ItemFieldTypeMenu,
+	"MONTH_YEAR":         onepassword.ItemFieldTypeMonthYear,
+	"ADDRESS":            onepassword.ItemFieldTypeAddress,
+	"DATE":               onepassword.ItemFieldTypeDate,
+}
+
+// connectMaxIdleConns is the number of idle connections kept open to the Connect server,
+// so the connections are reused by data sources read in parallel instead of being reopened for each request.
+const connectMaxIdleConns = 16
+
+// newConnectClient creates a onepassword client talking to the 1Password Connect server at the given host,
+// identifying itself by the given User-Agent header and trusting the given CAs, nil meaning the system CAs.
+// Only read operations are supported by the returned client, which is safe for concurrent use.
+func newConnectClient(host string, token string, userAgent string, rootCAs *x509.CertPool) *onepassword.Client {
+	connect := &connectClient{
+		host:       strings.TrimSuffix(host, "/"),
+		token:      token,
+		userAgent:  userAgent,
+		httpClient: &http.Client{Transport: newConnectTransport(rootCAs)},
+	}
+	return &onepassword.Client{
+		SecretsAPI: &connectSecrets{connect},
+		ItemsAPI:   &connectItems{connect},
+		VaultsAPI:  &connectVaults{connect},
+	}
+}
+
+// newConnectTransport returns a copy of the default transport, including its proxy configuration,
+// keeping more idle connections per host than the default of two and trusting the given CAs, if any.
+func newConnectTransport(rootCAs *x509.CertPool) http.RoundTripper {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	transport = transport.Clone()
+	transport.MaxIdleConnsPerHost = connectMaxIdleConns
+	if rootCAs != nil {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+	return transport
+}
+
+// loadConnectCACerts returns the system CAs along with the PEM encoded CA certificates given inline or by the path of a PEM file,
+// so Connect servers using certificates of a private CA are trusted.
+func loadConnectCACerts(caCert string) (*x509.CertPool, error) {
+	pemCerts := []byte(caCert)
+	if !strings.Contains(caCert, "-----BEGIN") {
+		var err error
+		if pemCerts, err = os.ReadFile(caCert); err != nil {
+			return nil, err
+		}
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("no valid PEM encoded certificate found")
+	}
+	return pool, nil
+}
+
+// connectClient performs requests against the REST API of a 1Password Connect server.
+type connectClient struct {
+	host       string
+	token      string
+	userAgent  string
+	httpClient *http.Client
+}
+
+type connectVault struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type connectItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Vault struct {
+		ID string `json:"id"`
+	} `json:"vault"`
+	Category string `json:"category"`
+	URLs     []struct {
+		Href  string `json:"href"`
+		Label string `json:"label"`
+	} `json:"urls"`
+	Tags     []string `json:"tags"`
+	Version  uint32   `json:"version"`
+	Sections []struct {
+		ID    string `json:"id"`
+		Label string `json:"label"`
+	} `json:"sections"`
+	Fields []struct {
+		ID      string `json:"id"`
+		Section *struct {
+			ID string `json:"id"`
+		} `json:"section"`
+		Type    string `json:"type"`
+		Purpose string `json:"purpose"`
+		Label   string `json:"label"`
+		Value   string `json:"value"`
+		TOTP    string `json:"totp"`
+	} `json:"fields"`
+	Files []struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Size    uint32 `json:"size"`
+		Section *struct {
+			ID string `json:"id"`
+		} `json:"section"`
+	} `json:"files"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// get performs a GET request against the given API path, returning the raw response body.
+func (c *connectClient) get(ctx context.Context, path string) ([]byte, error) {
+	body, _, err := c.getWithHeader(ctx, path)
+	return body, err
+}
+
+// getWithHeader performs a GET request against the given API path like get, additionally returning the response header.
+func (c *connectClient) getWithHeader(ctx context.Context, path string) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiError := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(body, &apiError) != nil || apiError.Message == "" {
+			apiError.Message = strings.TrimSpace(string(body))
+		}
+		err := &connectStatusError{statusCode: resp.StatusCode, status: resp.Status, message: apiError.Message}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, nil, newRateLimitError(err, resp.Header)
+		}
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
+
+// connectStatusError is returned by the Connect client if the server responds with an error status,
+// so failures can be classified by their status code rather than by their message.
+type connectStatusError struct {
+	statusCode int
+	status     string
+	message    string
+}
+
+func (e *connectStatusError) Error() string {
+	return fmt.Sprintf("connect server responded with %s: %s", e.status, e.message)
+}
+
+// returns the HTTP status code of the Connect server response causing the given error, zero if the error was not caused by one.
+func connectStatusCode(err error) int {
+	var statusErr *connectStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode
+	}
+	return 0
+}
+
+// getJSON performs a GET request against the given API path, decoding the JSON response into result.
+func (c *connectClient) getJSON(ctx context.Context, path string, result any) error {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+// getAllPages performs GET requests against the given API path, decoding the JSON list of each response
+// and following the next page links of the Link header until the last page is reached.
+// Connect servers return complete lists, but proxies in front of them may split large lists into pages.
+// Next page links are only followed on the Connect server itself, so the token is never sent to another host.
+func getAllPages[T any](ctx context.Context, c *connectClient, path string) ([]T, error) {
+	var all []T
+	requested := map[string]bool{}
+	for path != "" && !requested[path] {
+		requested[path] = true
+		body, header, err := c.getWithHeader(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		var page []T
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		path = nextPagePath(header)
+	}
+	return all, nil
+}
+
+// returns the path and query of the next page link of the given response header, or an empty string if there is none.
+func nextPagePath(header http.Header) string {
+	for _, link := range header.Values("Link") {
+		for _, value := range strings.Split(link, ",") {
+			target, params, found := strings.Cut(strings.TrimSpace(value), ";")
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				if strings.ReplaceAll(strings.TrimSpace(param), `"`, "") != "rel=next" {
+					continue
+				}
+				next, err := url.Parse(strings.Trim(target, "<>"))
+				if err != nil {
+					return ""
+				}
+				return next.RequestURI()
+			}
+		}
+	}
+	return ""
+}
+
+func (c *connectClient) listVaults(ctx context.Context) ([]connectVault, error) {
+	return getAllPages[connectVault](ctx, c, "/v1/vaults")
+}
+
+func (c *connectClient) listItems(ctx context.Context, vaultID string) ([]connectItem, error) {
+	return getAllPages[connectItem](ctx, c, "/v1/vaults/"+url.PathEscape(vaultID)+"/items")
+}
+
+func (c *connectClient) getItem(ctx context.Context, vaultID string, itemID string) (connectItem, error) {
+	var item connectItem
+	err := c.getJSON(ctx, "/v1/vaults/"+url.PathEscape(vaultID)+"/items/"+url.PathEscape(itemID), &item)
+	return item, err
+}
+
+// toCategory converts the given Connect API category to its SDK counterpart.
+func toCategory(category string) onepassword.ItemCategory {
+	if sdkCategory, ok := connectCategories[category]; ok {
+		return sdkCategory
+	}
+	return onepassword.ItemCategoryUnsupported
+}
+
+// toItem converts the given Connect API item to its SDK counterpart.
+func (i connectItem) toItem() onepassword.Item {
+	item := onepassword.Item{
+		ID:        i.ID,
+		Title:     i.Title,
+		Category:  toCategory(i.Category),
+		VaultID:   i.Vault.ID,
+		Tags:      i.Tags,
+		Version:   i.Version,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+	for _, website := range i.URLs {
+		item.Websites = append(item.Websites, onepassword.Website{URL: website.Href, Label: website.Label})
+	}
+	for _, section := range i.Sections {
+		item.Sections = append(item.Sections, onepassword.ItemSection{ID: section.ID, Title: section.Label})
+	}
+	for _, field := range i.Fields {
+		// the notes of an item are stored as a dedicated field in the Connect API
+		if field.Purpose == "NOTES" {
+			item.Notes = field.Value
+			continue
+		}
+
+		fieldType, ok := connectFieldTypes[field.Type]
+		if !ok {
+			fieldType = onepassword.ItemFieldTypeUnsupported
+		}
+		itemField := onepassword.ItemField{
+			ID:        field.ID,
+			Title:     field.Label,
+			FieldType: fieldType,
+			Value:     field.Value,
+		}
+		if field.Section != nil {
+			itemField.SectionID = &field.Section.ID
+		}
+		if fieldType == onepassword.ItemFieldTypeTOTP {
+			code := field.TOTP
+			details := onepassword.NewItemFieldDetailsTypeVariantOTP(&onepassword.OTPFieldDetails{Code: &code})
+			itemField.Details = &details
+		}
+		item.Fields = append(item.Fields, itemField)
+	}
+	for _, file := range i.Files {
+		itemFile := onepassword.ItemFile{
+			Attributes: onepassword.FileAttributes{Name: file.Name, ID: file.ID, Size: file.Size},
+		}
+		if file.Section != nil {
+			itemFile.SectionID = file.Section.ID
+		}
+		item.Files = append(item.Files, itemFile)
+	}
+	// the file of a document is listed like any other file in the Connect API
+	if item.Category == onepassword.ItemCategoryDocument && len(item.Files) > 0 {
+		item.Document = &item.Files[0].Attributes
+	}
+	return item
+}
+
+// connectVaults implements the vaults API of the SDK using a Connect server.
+type connectVaults struct {
+	*connectClient
+}
+
+func (v *connectVaults) List(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	vaults, err := v.listVaults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []onepassword.VaultOverview{}
+	for _, vault := range vaults {
+		result = append(result, onepassword.VaultOverview{
+			ID:        vault.ID,
+			Title:     vault.Name,
+			CreatedAt: vault.CreatedAt,
+			UpdatedAt: vault.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// connectItems implements the items API of the SDK using a Connect server.
+type connectItems struct {
+	*connectClient
+}
+
+func (i *connectItems) Create(context.Context, onepassword.ItemCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errConnectUnsupported
+}
+
+func (i *connectItems) Get(ctx context.Context, vaultID string, itemID string) (onepassword.Item, error) {
+	item, err := i.getItem(ctx, vaultID, itemID)
+	if err != nil {
+		return onepassword.Item{}, err
+	}
+	return item.toItem(), nil
+}
+
+func (i *connectItems) Put(context.Context, onepassword.Item) (onepassword.Item, error) {
+	return onepassword.Item{}, errConnectUnsupported
+}
+
+func (i *connectItems) Delete(context.Context, string, string) error {
+	return errConnectUnsupported
+}
+
+func (i *connectItems) Archive(context.Context, string, string) error {
+	return errConnectUnsupported
+}
+
+func (i *connectItems) List(ctx context.Context, vaultID string, _ ...onepassword.ItemListFilter) ([]onepassword.ItemOverview, error) {
+	items, err := i.listItems(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+	result := []onepassword.ItemOverview{}
+	for _, item := range items {
+		overview := onepassword.ItemOverview{
+			ID:        item.ID,
+			Title:     item.Title,
+			Category:  toCategory(item.Category),
+			VaultID:   item.Vault.ID,
+			Tags:      item.Tags,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			State:     onepassword.ItemStateActive,
+		}
+		for _, website := range item.URLs {
+			overview.Websites = append(overview.Websites, onepassword.Website{URL: website.Href, Label: website.Label})
+		}
+		result = append(result, overview)
+	}
+	return result, nil
+}
+
+func (i *connectItems) Shares() onepassword.ItemsSharesAPI {
+	return &connectItemsShares{}
+}
+
+func (i *connectItems) Files() onepassword.ItemsFilesAPI {
+	return &connectItemsFiles{i.connectClient}
+}
+
+// connectItemsFiles implements the item files API of the SDK using a Connect server.
+type connectItemsFiles struct {
+	*connectClient
+}
+
+func (f *connectItemsFiles) Attach(context.Context, onepassword.Item, onepassword.FileCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errConnectUnsupported
+}
+
+func (f *connectItemsFiles) Read(ctx context.Context, vaultID string, itemID string, attr onepassword.FileAttributes) ([]byte, error) {
+	return f.get(ctx, "/v1/vaults/"+url.PathEscape(vaultID)+"/items/"+url.PathEscape(itemID)+"/files/"+url.PathEscape(attr.ID)+"/content")
+}
+
+func (f *connectItemsFiles) Delete(context.Context, onepassword.Item, string, string) (onepassword.Item, error) {
+	return onepassword.Item{}, errConnectUnsupported
+}
+
+func (f *connectItemsFiles) ReplaceDocument(context.Context, onepassword.Item, onepassword.DocumentCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errConnectUnsupported
+}
+
+// connectItemsShares implements the item shares API of the SDK, which is not available on Connect servers.
+type connectItemsShares struct{}
+
+func (s *connectItemsShares) GetAccountPolicy(context.Context, string, string) (onepassword.ItemShareAccountPolicy, error) {
+	return onepassword.ItemShareAccountPolicy{}, errConnectUnsupported
+}
+
+func (s *connectItemsShares) ValidateRecipients(context.Context, onepassword.ItemShareAccountPolicy, []string) ([]onepassword.ValidRecipient, error) {
+	return nil, errConnectUnsupported
+}
+
+func (s *connectItemsShares) Create(context.Context, onepassword.Item, onepassword.ItemShareAccountPolicy, onepassword.ItemShareParams) (string, error) {
+	return "", errConnectUnsupported
+}
+
+// connectSecrets implements the secrets API of the SDK using a Connect server,
+// resolving secret references by looking up the referenced vault, item and field.
+type connectSecrets struct {
+	*connectClient
+}
+
+func (s *connectSecrets) Resolve(ctx context.Context, secretReference string) (string, error) {
+	reference, err := parseSecretReference(secretReference)
+	if err != nil {
+		return "", err
+	}
+
+	vaults, err := s.listVaults(ctx)
+	if err != nil {
+		return "", err
+	}
+	vaultCandidates := make([]connectCandidate, 0, len(vaults))
+	for _, vault := range vaults {
+		vaultCandidates = append(vaultCandidates, connectCandidate{id: vault.ID, title: vault.Name})
+	}
+	vaultID, err := uniqueConnectMatch("vault", reference.vault, vaultCandidates)
+	if err != nil {
+		return "", err
+	}
+	if vaultID == "" {
+		return "", fmt.Errorf("%w: '%s'", errVaultNotFound, reference.vault)
+	}
+
+	items, err := s.listItems(ctx, vaultID)
+	if err != nil {
+		return "", err
+	}
+	itemCandidates := make([]connectCandidate, 0, len(items))
+	for _, item := range items {
+		itemCandidates = append(itemCandidates, connectCandidate{id: item.ID, title: item.Title})
+	}
+	itemID, err := uniqueConnectMatch("item", reference.item, itemCandidates)
+	if err != nil {
+		return "", err
+	}
+	if itemID == "" {
+		return "", fmt.Errorf("%w: '%s' in the vault with ID '%s'", errItemNotFound, reference.item, vaultID)
+	}
+
+	item, err := s.getItem(ctx, vaultID, itemID)
+	if err != nil {
+		return "", err
+	}
+	sdkItem := item.toItem()
+	var fieldCandidates []connectCandidate
+	var matches []onepassword.ItemField
+	for _, field := range sdkItem.Fields {
+		if reference.section != "" && (field.SectionID == nil || !sectionMatches(sdkItem, *field.SectionID, reference.section)) {
+			continue
+		}
+		fieldCandidates = append(fieldCandidates, connectCandidate{id: field.ID, title: field.Title})
+		matches = append(matches, field)
+	}
+	fieldID, err := uniqueConnectMatch("field", reference.field, fieldCandidates)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range matches {
+		if field.ID == fieldID {
+			return fieldAttribute(field, reference.attribute)
+		}
+	}
+	return "", fmt.Errorf("%w: '%s' in item '%s' of the vault with ID '%s'", errFieldNotFound, reference.field, reference.item, vaultID)
+}
+
+// connectCandidate is a vault, item or field which may be named by a secret reference.
+type connectCandidate struct {
+	id    string
+	title string
+}
+
+// returns the ID of the candidate with the given ID or else with the given title, an empty string if none matches,
+// or an error listing the conflicting IDs if several candidates share the title, like getVaultId and getItemId,
+// so ambiguous references are not resolved to whichever candidate the Connect server happens to list first.
+func uniqueConnectMatch(kind string, name string, candidates []connectCandidate) (string, error) {
+	var matchIds, conflicts []string
+	for _, candidate := range candidates {
+		if candidate.id == name {
+			return candidate.id, nil
+		}
+		if candidate.title == name {
+			matchIds = append(matchIds, candidate.id)
+			conflicts = append(conflicts, fmt.Sprintf("'%s' (%s)", candidate.title, candidate.id))
+		}
+	}
+	switch len(matchIds) {
+	case 0:
+		return "", nil
+	case 1:
+		return matchIds[0], nil
+	}
+	return "", fmt.Errorf("%w: %s '%s' matches the %ss %s, use the %s ID instead", errAmbiguousMatch, kind, name, kind, strings.Join(conflicts, ", "), kind)
+}
+
+func (s *connectSecrets) ResolveAll(context.Context, []string) (onepassword.ResolveAllResponse, error) {
+	return onepassword.ResolveAllResponse{}, errConnectUnsupported
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newTestConnectServer serves the given JSON responses by request path, like a Connect server.
+func newTestConnectServer(t *testing.T, responses map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"message":"not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConnectSecretsResolveRejectsDuplicateTitles(t *testing.T) {
+	shared, prodA, prodB := testId("shared"), testId("proda"), testId("prodb")
+	database, duplicateA, duplicateB := testId("database"), testId("duplicatea"), testId("duplicateb")
+	server := newTestConnectServer(t, map[string]any{
+		"/v1/vaults": []map[string]any{
+			{"id": shared, "name": "Shared"},
+			{"id": prodA, "name": "Prod"},
+			{"id": prodB, "name": "Prod"},
+		},
+		"/v1/vaults/" + shared + "/items": []map[string]any{
+			{"id": database, "title": "Database"},
+			{"id": duplicateA, "title": "Duplicate"},
+			{"id": duplicateB, "title": "Duplicate"},
+		},
+		"/v1/vaults/" + prodA + "/items": []map[string]any{},
+		"/v1/vaults/" + shared + "/items/" + database: map[string]any{
+			"id":    database,
+			"title": "Database",
+			"fields": []map[string]any{
+				{"id": "password", "label": "password", "type": "CONCEALED", "value": "secret-password"},
+				{"id": "hosta", "label": "host", "type": "STRING", "value": "a.example.com"},
+				{"id": "hostb", "label": "host", "type": "STRING", "value": "b.example.com"},
+			},
+		},
+	})
+	secrets := newConnectClient(server.URL, "token", "test", nil).Secrets()
+
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+		wantErr   error
+	}{
+		{name: "unique titles", reference: "op://Shared/Database/password", want: "secret-password"},
+		{name: "ids", reference: "op://" + shared + "/" + database + "/password", want: "secret-password"},
+		{name: "duplicate vault title", reference: "op://Prod/Database/password", wantErr: errAmbiguousMatch},
+		{name: "vault id among duplicate titles", reference: "op://" + prodA + "/Database/password", wantErr: errItemNotFound},
+		{name: "duplicate item title", reference: "op://Shared/Duplicate/password", wantErr: errAmbiguousMatch},
+		{name: "duplicate field label", reference: "op://Shared/Database/host", wantErr: errAmbiguousMatch},
+		{name: "field id among duplicate labels", reference: "op://Shared/Database/hostb", want: "b.example.com"},
+		{name: "unknown field", reference: "op://Shared/Database/username", wantErr: errFieldNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := secrets.Resolve(context.Background(), test.reference)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Resolve(%q) error = %v, want %v", test.reference, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Resolve(%q) = %q, want %q", test.reference, got, test.want)
+			}
+		})
+	}
+}
+
+func TestConcurrentResolutionWithSharedClient(t *testing.T) {
+	const referenceCount = 50
+	shared := testId("shared")
+	responses := map[string]any{
+		"/v1/vaults": []map[string]any{{"id": shared, "name": "Shared"}},
+	}
+	items := []map[string]any{}
+	for i := range referenceCount {
+		itemId := testId(fmt.Sprintf("item%02d", i))
+		items = append(items, map[string]any{"id": itemId, "title": fmt.Sprintf("Service %d", i)})
+		responses["/v1/vaults/"+shared+"/items/"+itemId] = map[string]any{
+			"id":    itemId,
+			"title": fmt.Sprintf("Service %d", i),
+			"fields": []map[string]any{
+				{"id": "password", "label": "password", "type": "CONCEALED", "value": fmt.Sprintf("secret-%d", i)},
+			},
+		}
+	}
+	responses["/v1/vaults/"+shared+"/items"] = items
+	server := newTestConnectServer(t, responses)
+
+	// all data sources of a terraform run share the resolver and its client, reading them in parallel
+	client := newConnectClient(server.URL, "token", "test", nil)
+	resolver := &secretReferenceResolver{client: client, lookup: newClientLookup(client), cache: newLookupCache(), secrets: newSecretCache(), redactor: newRedactor()}
+
+	var waitGroup sync.WaitGroup
+	errs := make(chan error, referenceCount)
+	for i := range referenceCount {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			reference := fmt.Sprintf("op://Shared/Service %d/password", i)
+			secret, err := resolver.resolve(context.Background(), reference, fileEncodingBase64)
+			switch {
+			case err != nil:
+				errs <- fmt.Errorf("resolve(%q) failed: %w", reference, err)
+			case secret.value != fmt.Sprintf("secret-%d", i):
+				errs <- fmt.Errorf("resolve(%q) = %q, want %q", reference, secret.value, fmt.Sprintf("secret-%d", i))
+			}
+		}()
+	}
+	waitGroup.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestNextPagePath(t *testing.T) {
+	tests := []struct {
+		name string
+		link []string
+		want string
+	}{
+		{name: "no link"},
+		{name: "relative next link", link: []string{`</v1/vaults?page=2>; rel="next"`}, want: "/v1/vaults?page=2"},
+		{name: "absolute next link", link: []string{`<https://connect.example.com/v1/vaults?page=2>; rel="next"`}, want: "/v1/vaults?page=2"},
+		{name: "unquoted rel", link: []string{`</v1/vaults?page=2>; rel=next`}, want: "/v1/vaults?page=2"},
+		{name: "multiple links", link: []string{`</v1/vaults?page=1>; rel="prev", </v1/vaults?page=3>; rel="next"`}, want: "/v1/vaults?page=3"},
+		{name: "multiple headers", link: []string{`</v1/vaults?page=9>; rel="last"`, `</v1/vaults?page=3>; rel="next"`}, want: "/v1/vaults?page=3"},
+		{name: "last page", link: []string{`</v1/vaults?page=1>; rel="first"`}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, link := range test.link {
+				header.Add("Link", link)
+			}
+			if got := nextPagePath(header); got != test.want {
+				t.Errorf("nextPagePath(%q) = %q, want %q", test.link, got, test.want)
+			}
+		})
+	}
+}
+
+func TestConnectListingsFollowNextPageLinks(t *testing.T) {
+	const pageSize, itemCount = 100, 250
+	shared, other := testId("shared"), testId("other")
+	var items []map[string]any
+	for i := range itemCount {
+		items = append(items, map[string]any{"id": testId(fmt.Sprintf("item%03d", i)), "title": fmt.Sprintf("Service %d", i)})
+	}
+	lastItem := testId(fmt.Sprintf("item%03d", itemCount-1))
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page any
+		switch r.URL.Path {
+		case "/v1/vaults":
+			// the first page links to the second one by an absolute URL
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", `<`+server.URL+`/v1/vaults?page=2>; rel="next"`)
+				page = []map[string]any{{"id": other, "name": "Other"}}
+			} else {
+				page = []map[string]any{{"id": shared, "name": "Shared"}}
+			}
+		case "/v1/vaults/" + shared + "/items":
+			start, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := min(start+pageSize, itemCount)
+			if end < itemCount {
+				w.Header().Set("Link", fmt.Sprintf(`</v1/vaults/%s/items?offset=%d>; rel="next"`, shared, end))
+			}
+			page = items[start:end]
+		case "/v1/vaults/" + shared + "/items/" + lastItem:
+			page = map[string]any{
+				"id":     lastItem,
+				"title":  fmt.Sprintf("Service %d", itemCount-1),
+				"fields": []map[string]any{{"id": "password", "label": "password", "type": "CONCEALED", "value": "last-password"}},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"message":"not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newConnectClient(server.URL, "token", "test", nil)
+	resolver := newTestResolver(newClientLookup(client))
+
+	vaults, err := resolver.listVaults(context.Background())
+	if err != nil || len(vaults) != 2 {
+		t.Fatalf("listVaults = %d vaults, %v, want the vaults of both pages", len(vaults), err)
+	}
+	itemId, err := resolver.getItemId(context.Background(), shared, fmt.Sprintf("Service %d", itemCount-1))
+	if err != nil || itemId != lastItem {
+		t.Errorf("getItemId = %q, %v, want the item %q of the last page", itemId, err, lastItem)
+	}
+	value, err := client.Secrets().Resolve(context.Background(), fmt.Sprintf("op://Shared/Service %d/password", itemCount-1))
+	if err != nil || value != "last-password" {
+		t.Errorf("Resolve = %q, %v, want the password of the item of the last page", value, err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &creditCardDataSource{}
+	_ datasource.DataSourceWithConfigure = &creditCardDataSource{}
+)
+
+func NewCreditCardDataSource() datasource.DataSource {
+	return &creditCardDataSource{}
+}
+
+type creditCardDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type creditCardDataSourceModel struct {
+	Vault      types.String `tfsdk:"vault"`
+	Item       types.String `tfsdk:"item"`
+	ID         types.String `tfsdk:"id"`
+	Cardholder types.String `tfsdk:"cardholder"`
+	Type       types.String `tfsdk:"type"`
+	Number     types.String `tfsdk:"number"`
+	Cvv        types.String `tfsdk:"cvv"`
+	Expiry     types.String `tfsdk:"expiry"`
+	ValidFrom  types.String `tfsdk:"valid_from"`
+	Pin        types.String `tfsdk:"pin"`
+}
+
+func (d *creditCardDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *creditCardDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credit_card"
+}
+
+func (d *creditCardDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the built-in fields of a credit card item at once, e.g. for payment test fixtures.<br>" +
+			"Fields missing in the item are null. Custom fields can be read using `opsecret_field` or `opsecret_item_fields`.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the credit card item.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"cardholder": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the cardholder.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the card, e.g. `visa` or `mc`.",
+			},
+			"number": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The card number.",
+			},
+			"cvv": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The verification number of the card.",
+			},
+			"expiry": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The expiry date of the card, formatted as stored by 1Password.",
+			},
+			"valid_from": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The date the card is valid from, formatted as stored by 1Password.",
+			},
+			"pin": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The PIN of the card.",
+			},
+		},
+	}
+}
+
+func (d *creditCardDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state creditCardDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+	if item.Category != onepassword.ItemCategoryCreditCard {
+		resp.Diagnostics.AddError(
+			"Unable to read credit card",
+			fmt.Sprintf("item '%s' is not a credit card but of category %s", item.Title, item.Category),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	state.Cardholder = builtInFieldValue(item, "cardholder", "cardholder name")
+	state.Type = builtInFieldValue(item, "type")
+	state.Number = builtInFieldValue(item, "ccnum", "number")
+	state.Cvv = builtInFieldValue(item, "cvv", "verification number")
+	state.Expiry = builtInFieldValue(item, "expiry", "expiry date")
+	state.ValidFrom = builtInFieldValue(item, "validFrom", "valid from")
+	state.Pin = builtInFieldValue(item, "pin")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// returns the value of the first field of the given item with the given ID or label, ignoring the case of labels,
+// or null if the item has no such field. Built-in fields of categories like credit cards and identities are identified by fixed IDs,
+// while the labels cover items imported from other password managers which may use generated IDs.
+func builtInFieldValue(item onepassword.Item, fieldId string, labels ...string) types.String {
+	for _, field := range item.Fields {
+		if field.ID == fieldId {
+			return types.StringValue(field.Value)
+		}
+	}
+	for _, field := range item.Fields {
+		if strings.EqualFold(field.Title, fieldId) {
+			return types.StringValue(field.Value)
+		}
+		for _, label := range labels {
+			if strings.EqualFold(field.Title, label) {
+				return types.StringValue(field.Value)
+			}
+		}
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &decodeFileFunction{}
+
+func NewDecodeFileFunction(provider *OPSecretReferenceProvider) function.Function {
+	return &decodeFileFunction{provider: provider}
+}
+
+type decodeFileFunction struct {
+	provider *OPSecretReferenceProvider
+}
+
+func (f *decodeFileFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_file"
+}
+
+func (f *decodeFileFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Reads the content of a file attachment or document referenced by a 1Password secret reference",
+		MarkdownDescription: "Resolves the given 1Password secret reference to a file attachment or document and returns its raw content, " +
+			"like combining `base64decode` with the base64 encoded value of `resolve`. References to fields and binary files, which are no valid UTF-8 text, are rejected.<br>" +
+			"Terraform does not allow functions to mark their results as sensitive, so wrap the result in `sensitive` if it is not passed to a sensitive attribute directly.<br>" +
+			"If the provider has not been configured yet, the OP_CONNECT_HOST and OP_CONNECT_TOKEN or the OP_SERVICE_ACCOUNT_TOKEN environment variables are used to authenticate.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "reference",
+				MarkdownDescription: "The 1Password secret reference of the file attachment or document, e.g. `op://vault/item/file.pem`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *decodeFileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secretReference string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secretReference))
+	if resp.Error != nil {
+		return
+	}
+
+	resolver, err := f.provider.functionResolver(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to create onepassword client: " + err.Error())
+		return
+	}
+
+	resolved, err := resolver.resolve(ctx, secretReference, fileEncodingRaw)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to read secret reference: "+err.Error())
+		return
+	}
+	if resolved.file == nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The secret reference '%s' points to a field instead of a file attachment or document, use resolve to read fields", secretReference))
+		return
+	}
+	if len(resolved.file.content) == 0 && resolver.failOnEmptyValue {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The file of the secret reference '%s' is empty", secretReference))
+		return
+	}
+
+	// terraform strings are UTF-8, so binary content would be mangled instead of returned as it is
+	if !utf8.Valid(resolved.file.content) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+			"The file '%s' of the secret reference '%s' is binary and cannot be returned as string, use resolve to read it base64 encoded instead",
+			resolved.file.attributes.Name, secretReference,
+		))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(resolved.file.content)))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDecodeFileFunction(t *testing.T) {
+	lookup := newTestAccount()
+	shared := testId("shared")
+	lookup.items[shared] = append(lookup.items[shared], onepassword.Item{
+		ID:    testId("binary"),
+		Title: "Keystore",
+		Files: []onepassword.ItemFile{{Attributes: onepassword.FileAttributes{ID: testId("keystore"), Name: "keystore.p12", Size: 4}}},
+	})
+	lookup.files[testId("keystore")] = []byte{0x30, 0x82, 0xff, 0xfe}
+
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+		wantErr   bool
+	}{
+		{name: "text file", reference: "op://Shared/Database/config.json", want: `{"debug":true}`},
+		{name: "binary file", reference: "op://Shared/Keystore/keystore.p12", wantErr: true},
+		{name: "field", reference: "op://Shared/Database/password", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decodeFile := NewDecodeFileFunction(&OPSecretReferenceProvider{resolver: newTestResolver(lookup)})
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.reference)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			decodeFile.Run(context.Background(), req, resp)
+			if (resp.Error != nil) != test.wantErr {
+				t.Fatalf("decode_file(%q) error = %v, want error %v", test.reference, resp.Error, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("decode_file(%q) = %s, want %q", test.reference, got, test.want)
+			}
+		})
+	}
+}
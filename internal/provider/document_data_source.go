@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &documentDataSource{}
+	_ datasource.DataSourceWithConfigure = &documentDataSource{}
+)
+
+func NewDocumentDataSource() datasource.DataSource {
+	return &documentDataSource{}
+}
+
+type documentDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type documentDataSourceModel struct {
+	Vault         types.String `tfsdk:"vault"`
+	Item          types.String `tfsdk:"item"`
+	LookupBy      types.String `tfsdk:"lookup_by"`
+	Encoding      types.String `tfsdk:"encoding"`
+	Trim          types.Bool   `tfsdk:"trim"`
+	LineEndings   types.String `tfsdk:"line_endings"`
+	Content       types.String `tfsdk:"content"`
+	FileName      types.String `tfsdk:"file_name"`
+	ContentType   types.String `tfsdk:"content_type"`
+	Size          types.Int64  `tfsdk:"size"`
+	ContentSha256 types.String `tfsdk:"content_sha256"`
+}
+
+func (d *documentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *documentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document"
+}
+
+func (d *documentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the file of an item of the `Document` category.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the document.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the document item.",
+			},
+			"lookup_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How to interpret the `vault` and `item`, one of `auto`, `name` or `id`. Defaults to `auto`.<br>" +
+					"`auto` uses values looking like 1Password IDs as IDs and looks up all other values by title, `name` always looks up the values by title " +
+					"and `id` always uses the values as IDs without listing vaults or items.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookupByAuto, lookupByName, lookupById),
+				},
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of the document content, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"trim": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove leading and trailing whitespace like spaces, tabs and newlines from the document content before encoding it. Defaults to `false`.",
+			},
+			"line_endings": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Convert the line endings of the document content before encoding it, one of `preserve`, `lf` or `crlf`. Defaults to `preserve`.<br>`lf` converts CRLF line endings to LF, `crlf` converts LF line endings to CRLF. Applied after `trim`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lineEndingsPreserve, lineEndingsLf, lineEndingsCrlf),
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The content of the document in the requested encoding.",
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The file name of the document.",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The MIME type of the document, derived from the file name or detected from the content.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the document in bytes as stored in 1Password, before trimming or converting line endings.",
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the document content in hex encoding, computed after trimming and converting line endings if enabled but before applying `encoding`.<br>Not sensitive, so it can be used to detect changes of the content in plans.",
+			},
+		},
+	}
+}
+
+func (d *documentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state documentDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// bound all calls of this read together, on top of the timeout of each call
+	readCtx, cancel := d.resolver.withReadTimeout(ctx)
+	defer cancel()
+
+	item, err := d.resolver.withLookupBy(state.LookupBy.ValueString()).getItem(readCtx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+	if item.Document == nil {
+		resp.Diagnostics.AddError(
+			"Unable to read document",
+			fmt.Sprintf("item '%s' of category '%s' is not a document", item.Title, item.Category),
+		)
+		return
+	}
+
+	if err := d.resolver.checkFileSize(*item.Document); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read document",
+			err.Error(),
+		)
+		return
+	}
+
+	content, err := d.resolver.readFile(readCtx, item.VaultID, item.ID, *item.Document)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read document",
+			withReadProgress(readCtx, err, fmt.Sprintf("reading the content of the document '%s'", item.Title)).Error(),
+		)
+		return
+	}
+	if state.Trim.ValueBool() {
+		content = bytes.TrimSpace(content)
+	}
+	content = convertLineEndings(content, state.LineEndings.ValueString())
+	document := fileAttachment{attributes: *item.Document, content: content}
+
+	state.Content = types.StringValue(encodeFileContent(document.content, state.Encoding.ValueString()))
+	state.FileName = types.StringValue(document.attributes.Name)
+	state.ContentType = types.StringValue(document.contentType())
+	state.Size = types.Int64Value(int64(document.attributes.Size))
+	state.ContentSha256 = types.StringValue(contentHash(document.content))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
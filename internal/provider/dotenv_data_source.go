@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dotenvDataSource{}
+	_ datasource.DataSourceWithConfigure = &dotenvDataSource{}
+)
+
+var (
+	// dotenvKeyPattern matches the names of environment variables portable across shells and dotenv implementations.
+	dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// dotenvUnquotedPattern matches values which can be written without quotes.
+	dotenvUnquotedPattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]*$`)
+	dotenvEscaper         = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"$", `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+)
+
+func NewDotenvDataSource() datasource.DataSource {
+	return &dotenvDataSource{}
+}
+
+type dotenvDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type dotenvDataSourceModel struct {
+	References map[string]string `tfsdk:"references"`
+	Encoding   types.String      `tfsdk:"encoding"`
+	Account    types.String      `tfsdk:"account"`
+	Content    types.String      `tfsdk:"content"`
+}
+
+func (d *dotenvDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *dotenvDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dotenv"
+}
+
+func (d *dotenvDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves multiple 1Password secret references and renders them in the `.env` format, one `KEY=value` line per reference.<br>" +
+			"Values containing whitespace, newlines, quotes or other special characters are double-quoted and escaped.",
+		Attributes: map[string]schema.Attribute{
+			"references": schema.MapAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The 1Password secret references to resolve, keyed by the name of the environment variable.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(dotenvKeyPattern, "must be a valid environment variable name")),
+					mapvalidator.ValueStringsAre(secretReferenceValidator{}),
+				},
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of file attachment contents, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The resolved secret values in the `.env` format, sorted by key.",
+			},
+		},
+	}
+}
+
+func (d *dotenvDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dotenvDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := d.resolver.forAccount(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	// bound all calls of this read together, on top of the timeout of each call
+	readCtx, cancel := resolver.withReadTimeout(ctx)
+	defer cancel()
+
+	resolved, failed := resolver.resolveAll(readCtx, state.References, state.Encoding.ValueString())
+	if len(failed) > 0 {
+		// report the failed references in a stable order
+		failedKeys := make([]string, 0, len(failed))
+		for key := range failed {
+			failedKeys = append(failedKeys, key)
+		}
+		sort.Strings(failedKeys)
+		for _, key := range failedKeys {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("references").AtMapKey(key),
+				readErrorSummary(failed[key]),
+				fmt.Sprintf("Resolving the secret reference with key '%s' failed: %s", key, failed[key].Error()),
+			)
+		}
+		return
+	}
+
+	values := make(map[string]string, len(resolved))
+	for key, secret := range resolved {
+		resolver.checkEmptyValue(&resp.Diagnostics, path.Root("references").AtMapKey(key), state.References[key], secret)
+		values[key] = secret.value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Content = types.StringValue(formatDotenv(values))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// renders the given values as KEY=value lines sorted by key, quoting values which are not safe to write as is.
+func formatDotenv(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var content strings.Builder
+	for _, key := range keys {
+		content.WriteString(key)
+		content.WriteByte('=')
+		content.WriteString(quoteDotenvValue(values[key]))
+		content.WriteByte('\n')
+	}
+	return content.String()
+}
+
+// returns the value as is if it only consists of safe characters,
+// otherwise double-quoted with backslashes, quotes, variable expansions and control characters escaped.
+func quoteDotenvValue(value string) string {
+	if dotenvUnquotedPattern.MatchString(value) {
+		return value
+	}
+	return `"` + dotenvEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &fieldDataSource{}
+	_ datasource.DataSourceWithConfigure = &fieldDataSource{}
+)
+
+func NewFieldDataSource() datasource.DataSource {
+	return &fieldDataSource{}
+}
+
+type fieldDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type fieldDataSourceModel struct {
+	Vault    types.String `tfsdk:"vault"`
+	Item     types.String `tfsdk:"item"`
+	LookupBy types.String `tfsdk:"lookup_by"`
+	Section  types.String `tfsdk:"section"`
+	Field    types.String `tfsdk:"field"`
+	Version  types.Int64  `tfsdk:"version"`
+	ID       types.String `tfsdk:"id"`
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+}
+
+func (d *fieldDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *fieldDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_field"
+}
+
+func (d *fieldDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a single field of an item, addressed by its section and label instead of a secret reference.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"lookup_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How to interpret the `vault` and `item`, one of `auto`, `name` or `id`. Defaults to `auto`.<br>" +
+					"`auto` uses values looking like 1Password IDs as IDs and looks up all other values by title, `name` always looks up the values by title " +
+					"and `id` always uses the values as IDs without listing vaults or items.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookupByAuto, lookupByName, lookupById),
+				},
+			},
+			"section": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The title or ID of the section containing the field.<br>If omitted, the field label must be unique within the item.",
+			},
+			"field": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The label or ID of the field.",
+			},
+			"version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The version of the item to read. Fails if the item has been updated since, as 1Password only provides the latest version of items.<br>If omitted, the latest version is read.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the field.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the field, e.g. `Text`, `Concealed` for passwords or `Totp` for one-time passwords.",
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The value of the field.",
+			},
+		},
+	}
+}
+
+func (d *fieldDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state fieldDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.withLookupBy(state.LookupBy.ValueString()).getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := checkItemVersion(item, state.Version.ValueInt64Pointer()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Item Version Not Available",
+			err.Error(),
+		)
+		return
+	}
+
+	field, err := getField(item, state.Section.ValueString(), state.Field.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read field",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(field.ID)
+	state.Type = types.StringValue(string(field.FieldType))
+	state.Value = types.StringValue(field.Value)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// searches the fields of the given item, matching by given field label or ID
+// and by the title or ID of the section containing the field, if a section is given
+// returns the field and nil on a unique match, an empty field and an error object otherwise.
+func getField(item onepassword.Item, sectionName string, fieldName string) (onepassword.ItemField, error) {
+	var matches []onepassword.ItemField
+	for _, field := range item.Fields {
+		if field.Title != fieldName && field.ID != fieldName {
+			continue
+		}
+		if sectionName != "" && (field.SectionID == nil || !sectionMatches(item, *field.SectionID, sectionName)) {
+			continue
+		}
+		matches = append(matches, field)
+	}
+
+	switch {
+	case len(matches) == 0 && sectionName != "":
+		return onepassword.ItemField{}, fmt.Errorf("field '%s' not found in section '%s' of item '%s'", fieldName, sectionName, item.Title)
+	case len(matches) == 0:
+		return onepassword.ItemField{}, fmt.Errorf("field '%s' not found in item '%s'", fieldName, item.Title)
+	case len(matches) > 1:
+		fieldIds := make([]string, 0, len(matches))
+		for _, match := range matches {
+			fieldIds = append(fieldIds, fmt.Sprintf("'%s'", match.ID))
+		}
+		return onepassword.ItemField{}, fmt.Errorf(
+			"field '%s' matches the fields %s of item '%s', specify the section or use the field ID instead",
+			fieldName, strings.Join(fieldIds, ", "), item.Title,
+		)
+	}
+	return matches[0], nil
+}
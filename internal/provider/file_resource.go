@@ -0,0 +1,503 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &fileResource{}
+	_ resource.ResourceWithConfigure  = &fileResource{}
+	_ resource.ResourceWithModifyPlan = &fileResource{}
+)
+
+// defaultFileSection is the title of the section file attachments are stored in if no section is configured.
+const defaultFileSection = "Files"
+
+func NewFileResource() resource.Resource {
+	return &fileResource{}
+}
+
+type fileResource struct {
+	resolver *secretReferenceResolver
+}
+
+type fileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Vault         types.String `tfsdk:"vault"`
+	VaultID       types.String `tfsdk:"vault_id"`
+	Item          types.String `tfsdk:"item"`
+	ItemID        types.String `tfsdk:"item_id"`
+	Section       types.String `tfsdk:"section"`
+	SectionID     types.String `tfsdk:"section_id"`
+	FileName      types.String `tfsdk:"file_name"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Source        types.String `tfsdk:"source"`
+	ContentHash   types.String `tfsdk:"content_hash"`
+}
+
+func (r *fileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.resolver = resolver
+}
+
+func (r *fileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (r *fileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	contentSources := []path.Expression{
+		path.MatchRoot("content"),
+		path.MatchRoot("content_base64"),
+		path.MatchRoot("source"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a file attachment to an existing 1Password item.<br>The attachment is only replaced if its content changes. The service account needs write access to the vault.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the field holding the file attachment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault containing the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item to attach the file to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item the file is attached to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"section": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultFileSection),
+				MarkdownDescription: "The title or ID of the item section to store the file in, created if missing. Defaults to `" + defaultFileSection + "`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"section_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the section the file is stored in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the file attachment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The text content of the file.<br>Exactly one of `content`, `content_base64` or `source` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(contentSources...),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The base64 encoded content of the file, to be used for binary files.",
+			},
+			"source": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path of a local file to upload.",
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the file content, in hex encoding.",
+			},
+		},
+	}
+}
+
+// ModifyPlan computes the hash of the configured file content, so changes of source files are detected
+// and the attachment is only replaced if its content actually changed.
+func (r *fileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(r.resolver, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan fileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Content.IsUnknown() || plan.ContentBase64.IsUnknown() || plan.Source.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+		return
+	}
+
+	content, err := fileContent(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read file content",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringValue(contentHash(content)))...)
+}
+
+func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := fileContent(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read file content",
+			err.Error(),
+		)
+		return
+	}
+
+	item, err := r.resolver.getItem(ctx, plan.Vault.ValueString(), plan.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to attach file",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, itemFile := range item.Files {
+		if itemFile.Attributes.Name == plan.FileName.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("file_name"),
+				"File Already Attached",
+				fmt.Sprintf("The item '%s' already has a file attachment named '%s'. Remove it or choose a different file name.", plan.Item.ValueString(), plan.FileName.ValueString()),
+			)
+			return
+		}
+	}
+
+	item, sectionId, err := r.ensureSection(ctx, item, plan.Section.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to attach file",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	item, fieldId, err := r.attach(ctx, item, sectionId, plan.FileName.ValueString(), content)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to attach file",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(fieldId)
+	plan.VaultID = types.StringValue(item.VaultID)
+	plan.ItemID = types.StringValue(item.ID)
+	plan.SectionID = types.StringValue(sectionId)
+	plan.ContentHash = types.StringValue(contentHash(content))
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// the item was deleted outside of terraform, so the file needs to be attached again
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read file",
+			err.Error(),
+		)
+		return
+	}
+
+	itemFile, found := findItemFile(item, state.ID.ValueString())
+	if !found {
+		// the file was removed outside of terraform and needs to be attached again
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	content, err := r.resolver.readFile(ctx, item.VaultID, item.ID, itemFile.Attributes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read file",
+			err.Error(),
+		)
+		return
+	}
+
+	state.FileName = types.StringValue(itemFile.Attributes.Name)
+	state.ContentHash = types.StringValue(contentHash(content))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state fileResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := fileContent(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read file content",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VaultID = state.VaultID
+	plan.ItemID = state.ItemID
+	plan.SectionID = state.SectionID
+	plan.ContentHash = types.StringValue(contentHash(content))
+
+	// the attachment only needs to be replaced if its content changed, e.g. not when switching from content to source
+	if plan.ContentHash.Equal(state.ContentHash) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to replace file",
+			err.Error(),
+		)
+		return
+	}
+
+	// the new content is attached before the previous attachment is deleted, so a failed upload keeps the previous file
+	item, fieldId, err := r.attach(ctx, item, state.SectionID.ValueString(), plan.FileName.ValueString(), content)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to replace file",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+	plan.ID = types.StringValue(fieldId)
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, found := findItemFile(item, state.ID.ValueString()); !found {
+		return
+	}
+	if _, err := r.resolver.deleteFile(ctx, item, state.SectionID.ValueString(), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete replaced file",
+			fmt.Sprintf("The new file content was attached, but the previous attachment could not be deleted and needs to be removed manually.\n\n%s", writeErrorDetail(err, plan.Vault.ValueString())),
+		)
+		return
+	}
+}
+
+func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to delete file",
+			err.Error(),
+		)
+		return
+	}
+
+	if _, found := findItemFile(item, state.ID.ValueString()); !found {
+		return
+	}
+
+	if _, err := r.resolver.deleteFile(ctx, item, state.SectionID.ValueString(), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete file",
+			writeErrorDetail(err, state.Vault.ValueString()),
+		)
+		return
+	}
+}
+
+// returns the ID of the section of the given item matching the given section title or ID,
+// adding a new section with the given title to the item if no section matches.
+func (r *fileResource) ensureSection(ctx context.Context, item onepassword.Item, sectionName string) (onepassword.Item, string, error) {
+	for _, section := range item.Sections {
+		if section.ID == sectionName || section.Title == sectionName {
+			return item, section.ID, nil
+		}
+	}
+
+	sectionId := newItemFieldId()
+	item.Sections = append(item.Sections, onepassword.ItemSection{ID: sectionId, Title: sectionName})
+	item, err := r.resolver.putItem(ctx, item)
+	if err != nil {
+		return onepassword.Item{}, "", err
+	}
+	return item, sectionId, nil
+}
+
+// attaches the given content as file with the given name to the given section of the given item,
+// returning the updated item and the ID of the field holding the file.
+func (r *fileResource) attach(ctx context.Context, item onepassword.Item, sectionId string, fileName string, content []byte) (onepassword.Item, string, error) {
+	fieldId := newItemFieldId()
+	updatedItem, err := r.resolver.attachFile(ctx, item, onepassword.FileCreateParams{
+		Name:      fileName,
+		Content:   content,
+		SectionID: sectionId,
+		FieldID:   fieldId,
+	})
+	if err != nil {
+		return onepassword.Item{}, "", err
+	}
+
+	if _, found := findItemFile(updatedItem, fieldId); !found {
+		return onepassword.Item{}, "", fmt.Errorf("the file '%s' is missing from the item '%s' after attaching it", fileName, updatedItem.Title)
+	}
+	return updatedItem, fieldId, nil
+}
+
+// returns the file stored in the field with the given ID of the given item, if any.
+func findItemFile(item onepassword.Item, fieldId string) (onepassword.ItemFile, bool) {
+	for _, itemFile := range item.Files {
+		if itemFile.FieldID == fieldId {
+			return itemFile, true
+		}
+	}
+	return onepassword.ItemFile{}, false
+}
+
+// returns the configured file content, read from either content, content_base64 or source.
+func fileContent(model fileResourceModel) ([]byte, error) {
+	switch {
+	case !model.ContentBase64.IsNull():
+		content, err := base64.StdEncoding.DecodeString(model.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 is not valid base64: %w", err)
+		}
+		return content, nil
+	case !model.Source.IsNull():
+		return os.ReadFile(model.Source.ValueString())
+	default:
+		return []byte(model.Content.ValueString()), nil
+	}
+}
+
+// returns the hex encoded SHA-256 hash of the given content.
+func contentHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeItems stores the items written through the client in the given lookup.
+type fakeItems struct {
+	// ItemsAPI is nil, calls of methods not implemented by the fake panic.
+	onepassword.ItemsAPI
+
+	lookup *fakeLookup
+	// attachErr is returned by Attach if set.
+	attachErr error
+	// deleted holds the field IDs of the deleted files, in order.
+	deleted []string
+}
+
+// newTestFileResource returns a file resource writing to the given lookup through a fake client.
+func newTestFileResource(lookup *fakeLookup) (*fileResource, *fakeItems) {
+	items := &fakeItems{lookup: lookup}
+	resolver := newTestResolver(lookup)
+	resolver.client = &onepassword.Client{ItemsAPI: items}
+	return &fileResource{resolver: resolver}, items
+}
+
+func (i *fakeItems) store(item onepassword.Item) {
+	i.lookup.mutex.Lock()
+	defer i.lookup.mutex.Unlock()
+	vaultItems := i.lookup.items[item.VaultID]
+	for index := range vaultItems {
+		if vaultItems[index].ID == item.ID {
+			vaultItems[index] = item
+		}
+	}
+}
+
+func (i *fakeItems) Put(_ context.Context, item onepassword.Item) (onepassword.Item, error) {
+	i.store(item)
+	return item, nil
+}
+
+func (i *fakeItems) Files() onepassword.ItemsFilesAPI {
+	return &fakeItemsFiles{i}
+}
+
+type fakeItemsFiles struct {
+	items *fakeItems
+}
+
+func (f *fakeItemsFiles) Attach(_ context.Context, item onepassword.Item, params onepassword.FileCreateParams) (onepassword.Item, error) {
+	if f.items.attachErr != nil {
+		return onepassword.Item{}, f.items.attachErr
+	}
+	f.items.lookup.mutex.Lock()
+	fileId := testId(fmt.Sprintf("upload%03d", len(f.items.lookup.files)))
+	f.items.lookup.files[fileId] = params.Content
+	f.items.lookup.mutex.Unlock()
+	item.Files = append(slices.Clone(item.Files), onepassword.ItemFile{
+		Attributes: onepassword.FileAttributes{ID: fileId, Name: params.Name, Size: uint32(len(params.Content))},
+		SectionID:  params.SectionID,
+		FieldID:    params.FieldID,
+	})
+	f.items.store(item)
+	return item, nil
+}
+
+func (f *fakeItemsFiles) Read(context.Context, string, string, onepassword.FileAttributes) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeItemsFiles) Delete(_ context.Context, item onepassword.Item, _ string, fieldId string) (onepassword.Item, error) {
+	item.Files = slices.DeleteFunc(slices.Clone(item.Files), func(itemFile onepassword.ItemFile) bool {
+		return itemFile.FieldID == fieldId
+	})
+	f.items.deleted = append(f.items.deleted, fieldId)
+	f.items.store(item)
+	return item, nil
+}
+
+func (f *fakeItemsFiles) ReplaceDocument(context.Context, onepassword.Item, onepassword.DocumentCreateParams) (onepassword.Item, error) {
+	return onepassword.Item{}, errors.New("not implemented")
+}
+
+// testFileModel returns the planned model of a file attached to the database item of the test account.
+func testFileModel() fileResourceModel {
+	return fileResourceModel{
+		ID:            types.StringUnknown(),
+		Vault:         types.StringValue("Shared"),
+		VaultID:       types.StringUnknown(),
+		Item:          types.StringValue("Database"),
+		ItemID:        types.StringUnknown(),
+		Section:       types.StringValue(defaultFileSection),
+		SectionID:     types.StringUnknown(),
+		FileName:      types.StringValue("app.conf"),
+		Content:       types.StringValue("debug = false"),
+		ContentBase64: types.StringNull(),
+		Source:        types.StringNull(),
+		ContentHash:   types.StringUnknown(),
+	}
+}
+
+// fileResourceState returns an empty state of the file resource.
+func fileResourceState(t *testing.T, r *fileResource) tfsdk.State {
+	t.Helper()
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}
+}
+
+// fileResourcePlan returns a plan of the file resource holding the given model.
+func fileResourcePlan(t *testing.T, r *fileResource, model fileResourceModel) tfsdk.Plan {
+	t.Helper()
+	state := fileResourceState(t, r)
+	if diags := state.Set(context.Background(), &model); diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+	return tfsdk.Plan(state)
+}
+
+// createFile creates the file resource with the given plan, returning the resulting state.
+func createFile(t *testing.T, r *fileResource, plan fileResourceModel) (fileResourceModel, tfsdk.State) {
+	t.Helper()
+	resp := resource.CreateResponse{State: fileResourceState(t, r)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: fileResourcePlan(t, r, plan)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+	var state fileResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to read the created state: %v", resp.Diagnostics)
+	}
+	return state, resp.State
+}
+
+// updateFile updates the file resource from the given state to the given plan, returning the update response.
+func updateFile(t *testing.T, r *fileResource, state tfsdk.State, plan fileResourceModel) resource.UpdateResponse {
+	t.Helper()
+	resp := resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: fileResourcePlan(t, r, plan), State: state}, &resp)
+	return resp
+}
+
+// attachedContent returns the content of the file stored in the given field of the database item.
+func attachedContent(t *testing.T, lookup *fakeLookup, fieldId string) (string, bool) {
+	t.Helper()
+	item, err := lookup.GetItem(context.Background(), testId("shared"), testId("database"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	itemFile, found := findItemFile(item, fieldId)
+	if !found {
+		return "", false
+	}
+	return string(lookup.files[itemFile.Attributes.ID]), true
+}
+
+func TestFileResourceCreate(t *testing.T) {
+	lookup := newTestAccount()
+	r, _ := newTestFileResource(lookup)
+
+	state, _ := createFile(t, r, testFileModel())
+
+	if !onePasswordIdPattern.MatchString(state.ID.ValueString()) || !onePasswordIdPattern.MatchString(state.SectionID.ValueString()) {
+		t.Errorf("Create assigned the field ID %q and section ID %q, want valid 1Password IDs", state.ID.ValueString(), state.SectionID.ValueString())
+	}
+	if state.VaultID.ValueString() != testId("shared") || state.ItemID.ValueString() != testId("database") {
+		t.Errorf("Create stored the vault ID %q and item ID %q, want the IDs of the database item", state.VaultID.ValueString(), state.ItemID.ValueString())
+	}
+	if want := contentHash([]byte("debug = false")); state.ContentHash.ValueString() != want {
+		t.Errorf("Create stored the content hash %q, want %q", state.ContentHash.ValueString(), want)
+	}
+	if content, found := attachedContent(t, lookup, state.ID.ValueString()); !found || content != "debug = false" {
+		t.Errorf("Create attached the content %q (found %v), want %q", content, found, "debug = false")
+	}
+}
+
+func TestFileResourceCreateBinaryContent(t *testing.T) {
+	lookup := newTestAccount()
+	r, _ := newTestFileResource(lookup)
+	binary := []byte{0x00, 0xff, 0x10, 0x80}
+	plan := testFileModel()
+	plan.Content = types.StringNull()
+	plan.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(binary))
+
+	state, _ := createFile(t, r, plan)
+
+	if content, found := attachedContent(t, lookup, state.ID.ValueString()); !found || content != string(binary) {
+		t.Errorf("Create attached the content %x (found %v), want %x", content, found, binary)
+	}
+	if want := contentHash(binary); state.ContentHash.ValueString() != want {
+		t.Errorf("Create stored the content hash %q, want %q", state.ContentHash.ValueString(), want)
+	}
+}
+
+func TestFileResourceUpdateWithEqualContentKeepsFile(t *testing.T) {
+	lookup := newTestAccount()
+	r, items := newTestFileResource(lookup)
+	created, state := createFile(t, r, testFileModel())
+	callsBefore := lookup.callCount()
+
+	// switching from content to content_base64 keeps the content hash
+	plan := created
+	plan.Content = types.StringNull()
+	plan.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte("debug = false")))
+	resp := updateFile(t, r, state, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %v", resp.Diagnostics)
+	}
+	if calls := lookup.callCount() - callsBefore; calls != 0 || len(items.deleted) != 0 {
+		t.Errorf("Update made %d calls and deleted %v, want no calls", calls, items.deleted)
+	}
+	var updated fileResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &updated)...)
+	if updated.ID != created.ID {
+		t.Errorf("Update changed the field ID from %q to %q", created.ID.ValueString(), updated.ID.ValueString())
+	}
+}
+
+func TestFileResourceUpdateReplacesChangedContent(t *testing.T) {
+	lookup := newTestAccount()
+	r, items := newTestFileResource(lookup)
+	created, state := createFile(t, r, testFileModel())
+
+	plan := created
+	plan.Content = types.StringValue("debug = true")
+	resp := updateFile(t, r, state, plan)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %v", resp.Diagnostics)
+	}
+	var updated fileResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &updated)...)
+	if updated.ID == created.ID {
+		t.Fatalf("Update kept the field ID %q, want the field of the new attachment", created.ID.ValueString())
+	}
+	if content, found := attachedContent(t, lookup, updated.ID.ValueString()); !found || content != "debug = true" {
+		t.Errorf("Update attached the content %q (found %v), want %q", content, found, "debug = true")
+	}
+	if _, found := attachedContent(t, lookup, created.ID.ValueString()); found || !slices.Equal(items.deleted, []string{created.ID.ValueString()}) {
+		t.Errorf("Update deleted %v, want only the previous attachment %q", items.deleted, created.ID.ValueString())
+	}
+}
+
+func TestFileResourceUpdateKeepsFileIfAttachFails(t *testing.T) {
+	lookup := newTestAccount()
+	r, items := newTestFileResource(lookup)
+	created, state := createFile(t, r, testFileModel())
+	items.attachErr = errors.New("upload failed")
+
+	plan := created
+	plan.Content = types.StringValue("debug = true")
+	resp := updateFile(t, r, state, plan)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Update succeeded, want the attach error")
+	}
+	if content, found := attachedContent(t, lookup, created.ID.ValueString()); !found || content != "debug = false" || len(items.deleted) != 0 {
+		t.Errorf("Update left the content %q (found %v) and deleted %v, want the previous attachment kept", content, found, items.deleted)
+	}
+}
+
+func TestFileResourceReadRemovesMissingFile(t *testing.T) {
+	lookup := newTestAccount()
+	r, items := newTestFileResource(lookup)
+	created, state := createFile(t, r, testFileModel())
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Fatalf("Read of the attached file failed or removed it: %v", resp.Diagnostics)
+	}
+
+	item, err := lookup.GetItem(context.Background(), testId("shared"), testId("database"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := items.Files().Delete(context.Background(), item, created.SectionID.ValueString(), created.ID.ValueString()); err != nil {
+		t.Fatal(err)
+	}
+
+	resp = resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Read kept the file removed outside of terraform in the state")
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &generatedPasswordResource{}
+	_ resource.ResourceWithConfigure  = &generatedPasswordResource{}
+	_ resource.ResourceWithModifyPlan = &generatedPasswordResource{}
+)
+
+// generatedPasswordFieldId is the ID of the field holding the generated password in the created password item.
+const generatedPasswordFieldId = "password"
+
+// defaultGeneratedPasswordLength is the length of generated passwords if not configured.
+const defaultGeneratedPasswordLength = 32
+
+func NewGeneratedPasswordResource() resource.Resource {
+	return &generatedPasswordResource{}
+}
+
+type generatedPasswordResource struct {
+	resolver *secretReferenceResolver
+}
+
+type generatedPasswordResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Vault     types.String `tfsdk:"vault"`
+	VaultID   types.String `tfsdk:"vault_id"`
+	Title     types.String `tfsdk:"title"`
+	Length    types.Int64  `tfsdk:"length"`
+	Digits    types.Bool   `tfsdk:"digits"`
+	Symbols   types.Bool   `tfsdk:"symbols"`
+	Keepers   types.Map    `tfsdk:"keepers"`
+	Password  types.String `tfsdk:"password"`
+	Reference types.String `tfsdk:"reference"`
+}
+
+func (r *generatedPasswordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.resolver = resolver
+}
+
+func (r *generatedPasswordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_generated_password"
+}
+
+func (r *generatedPasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a random password and stores it in a new password item.<br>" +
+			"The password is only generated on creation. Changing any of the password options or `keepers` generates a new password in a new item. " +
+			"The service account needs write access to the vault.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault to create the item in. Changing the vault recreates the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault containing the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title of the item.",
+			},
+			"length": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultGeneratedPasswordLength),
+				MarkdownDescription: fmt.Sprintf("The length of the password, between 8 and 100. Defaults to `%d`.", defaultGeneratedPasswordLength),
+				Validators: []validator.Int64{
+					int64validator.Between(8, 100),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"digits": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Include at least one digit in the password. Defaults to `true`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"symbols": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Include at least one symbol in the password. Defaults to `true`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values which generate a new password in a new item when changed, like the `keepers` of the `random_password` resource.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The generated password.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reference": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The secret reference of the generated password, e.g. to resolve it with the ephemeral resource instead of reading it from the state.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects any change if the provider is read-only.
+func (r *generatedPasswordResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(r.resolver, req, &resp.Diagnostics)
+}
+
+func (r *generatedPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan generatedPasswordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := r.resolver.generatePassword(ctx, uint32(plan.Length.ValueInt64()), plan.Digits.ValueBool(), plan.Symbols.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to generate password",
+			err.Error(),
+		)
+		return
+	}
+
+	vaultId, err := r.resolver.getVaultId(ctx, plan.Vault.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create item",
+			err.Error(),
+		)
+		return
+	}
+
+	item, err := r.resolver.createItem(ctx, onepassword.ItemCreateParams{
+		VaultID:  vaultId,
+		Title:    plan.Title.ValueString(),
+		Category: onepassword.ItemCategoryPassword,
+		Fields: []onepassword.ItemField{
+			{
+				ID:        generatedPasswordFieldId,
+				Title:     generatedPasswordFieldId,
+				FieldType: onepassword.ItemFieldTypeConcealed,
+				Value:     password,
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create item",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(item.ID)
+	plan.VaultID = types.StringValue(item.VaultID)
+	plan.Password = types.StringValue(password)
+	plan.Reference = types.StringValue(secretReference{vault: item.VaultID, item: item.ID, field: generatedPasswordFieldId}.String())
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *generatedPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state generatedPasswordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// the item was deleted outside of terraform and needs to be recreated
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Title = types.StringValue(item.Title)
+	// the password may have been changed in 1Password in the meantime
+	for _, field := range item.Fields {
+		if field.ID == generatedPasswordFieldId {
+			state.Password = types.StringValue(field.Value)
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *generatedPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state generatedPasswordResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// all other attributes recreate the item, so only the title needs to be updated
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update item",
+			err.Error(),
+		)
+		return
+	}
+
+	item.Title = plan.Title.ValueString()
+	if _, err := r.resolver.putItem(ctx, item); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update item",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VaultID = state.VaultID
+	plan.Password = state.Password
+	plan.Reference = state.Reference
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *generatedPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state generatedPasswordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.resolver.deleteItem(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to delete item",
+			writeErrorDetail(err, state.Vault.ValueString()),
+		)
+		return
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &identityDataSource{}
+	_ datasource.DataSourceWithConfigure = &identityDataSource{}
+)
+
+func NewIdentityDataSource() datasource.DataSource {
+	return &identityDataSource{}
+}
+
+type identityDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type identityDataSourceModel struct {
+	Vault      types.String `tfsdk:"vault"`
+	Item       types.String `tfsdk:"item"`
+	ID         types.String `tfsdk:"id"`
+	FirstName  types.String `tfsdk:"first_name"`
+	Initial    types.String `tfsdk:"initial"`
+	LastName   types.String `tfsdk:"last_name"`
+	Gender     types.String `tfsdk:"gender"`
+	BirthDate  types.String `tfsdk:"birth_date"`
+	Occupation types.String `tfsdk:"occupation"`
+	Company    types.String `tfsdk:"company"`
+	Department types.String `tfsdk:"department"`
+	JobTitle   types.String `tfsdk:"job_title"`
+	Address    types.String `tfsdk:"address"`
+	Phone      types.String `tfsdk:"phone"`
+	Email      types.String `tfsdk:"email"`
+	Username   types.String `tfsdk:"username"`
+}
+
+func (d *identityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *identityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity"
+}
+
+func (d *identityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the built-in fields of an identity item at once, e.g. for test fixtures of user data.<br>" +
+			"Fields missing in the item are null. The date of birth, address and phone number are sensitive. Custom fields can be read using `opsecret_field` or `opsecret_item_fields`.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the identity item.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"first_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The first name.",
+			},
+			"initial": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The middle initial.",
+			},
+			"last_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The last name.",
+			},
+			"gender": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The gender.",
+			},
+			"birth_date": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The date of birth, formatted as stored by 1Password.",
+			},
+			"occupation": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The occupation.",
+			},
+			"company": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The company.",
+			},
+			"department": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The department.",
+			},
+			"job_title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The job title.",
+			},
+			"address": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The address, formatted as a single line as stored by 1Password.",
+			},
+			"phone": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The default phone number.",
+			},
+			"email": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The email address.",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username.",
+			},
+		},
+	}
+}
+
+func (d *identityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state identityDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+	if item.Category != onepassword.ItemCategoryIdentity {
+		resp.Diagnostics.AddError(
+			"Unable to read identity",
+			fmt.Sprintf("item '%s' is not an identity but of category %s", item.Title, item.Category),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	state.FirstName = builtInFieldValue(item, "firstname", "first name")
+	state.Initial = builtInFieldValue(item, "initial")
+	state.LastName = builtInFieldValue(item, "lastname", "last name")
+	state.Gender = builtInFieldValue(item, "sex", "gender")
+	state.BirthDate = builtInFieldValue(item, "birthdate", "birth date")
+	state.Occupation = builtInFieldValue(item, "occupation")
+	state.Company = builtInFieldValue(item, "company")
+	state.Department = builtInFieldValue(item, "department")
+	state.JobTitle = builtInFieldValue(item, "jobtitle", "job title")
+	state.Address = builtInFieldValue(item, "address")
+	state.Phone = builtInFieldValue(item, "defphone", "phone")
+	state.Email = builtInFieldValue(item, "email")
+	state.Username = builtInFieldValue(item, "username")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
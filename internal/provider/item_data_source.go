@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &itemDataSource{}
+	_ datasource.DataSourceWithConfigure = &itemDataSource{}
+)
+
+func NewItemDataSource() datasource.DataSource {
+	return &itemDataSource{}
+}
+
+type itemDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemDataSourceModel struct {
+	Vault     types.String `tfsdk:"vault"`
+	Vaults    []string     `tfsdk:"vaults"`
+	VaultID   types.String `tfsdk:"vault_id"`
+	Item      types.String `tfsdk:"item"`
+	LookupBy  types.String `tfsdk:"lookup_by"`
+	Pattern   types.String `tfsdk:"pattern"`
+	Version   types.Int64  `tfsdk:"version"`
+	ID        types.String `tfsdk:"id"`
+	Category  types.String `tfsdk:"category"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	Tags      types.List   `tfsdk:"tags"`
+	Fields    types.Map    `tfsdk:"fields"`
+}
+
+func (d *itemDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *itemDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item"
+}
+
+func (d *itemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads all fields of an item at once.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item. Exactly one of `vault` and `vaults` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("vaults")),
+				},
+			},
+			"vaults": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The titles or IDs of the vaults to search for the item in priority order, e.g. `[\"app-${var.environment}\", \"app-shared\"]`. " +
+					"The item is read from the first vault containing it, vaults which do not exist or are not accessible are skipped.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault the item was read from.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"lookup_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How to interpret the `vault` and `item`, one of `auto`, `name` or `id`. Defaults to `auto`.<br>" +
+					"`auto` uses values looking like 1Password IDs as IDs and looks up all other values by title, `name` always looks up the values by title " +
+					"and `id` always uses the values as IDs without listing vaults or items.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookupByAuto, lookupByName, lookupById),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The version of the item to read. Fails if the item has been updated since, as 1Password only provides the latest version of items.<br>If omitted, the latest version is read.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pattern": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A glob pattern like `DB_*` restricting `fields` to the fields with a matching label.<br>See https://pkg.go.dev/path/filepath#Match for the pattern syntax.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"category": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The category of the item, e.g. `Login`, `Password` or `Document`.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the item was last updated, in RFC 3339 format.",
+			},
+			"tags": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The tags of the item.",
+			},
+			"fields": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The values of all item fields matching the `pattern`, keyed by the field label.<br>If multiple fields share the same label, the first one is used and a warning is reported.",
+			},
+		},
+	}
+}
+
+func (d *itemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state itemDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaults := state.Vaults
+	if !state.Vault.IsNull() {
+		vaults = []string{state.Vault.ValueString()}
+	}
+	item, err := d.resolver.withLookupBy(state.LookupBy.ValueString()).getItemFromVaults(ctx, vaults, state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+	if err := checkItemVersion(item, state.Version.ValueInt64Pointer()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Item Version Not Available",
+			err.Error(),
+		)
+		return
+	}
+
+	pattern := state.Pattern.ValueString()
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pattern"),
+			"Invalid Pattern",
+			fmt.Sprintf("The pattern '%s' is not a valid glob pattern: %s", pattern, err.Error()),
+		)
+		return
+	}
+
+	fields, duplicateLabels := itemFieldValues(item.Fields, pattern)
+	if len(duplicateLabels) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("fields"),
+			"Duplicate Field Labels",
+			fmt.Sprintf("The item '%s' contains multiple fields labeled '%s', so only the first of them is part of fields. "+
+				"Use the opsecret_field data source with a section to read the other fields.", item.Title, strings.Join(duplicateLabels, "', '")),
+		)
+	}
+
+	// items without tags should expose an empty list instead of null
+	itemTags := item.Tags
+	if itemTags == nil {
+		itemTags = []string{}
+	}
+
+	tags, diags := types.ListValueFrom(ctx, types.StringType, itemTags)
+	resp.Diagnostics.Append(diags...)
+	fieldValues, diags := types.MapValue(types.StringType, fields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	state.VaultID = types.StringValue(item.VaultID)
+	state.Category = types.StringValue(string(item.Category))
+	state.UpdatedAt = types.StringValue(item.UpdatedAt.Format(time.RFC3339))
+	state.Tags = tags
+	state.Fields = fieldValues
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// verifies that the given item has the given version, if any. Items can only be read in their latest version,
+// so older versions cannot be read and newer versions do not exist yet.
+func checkItemVersion(item onepassword.Item, version *int64) error {
+	switch {
+	case version == nil || *version == int64(item.Version):
+		return nil
+	case *version > int64(item.Version):
+		return fmt.Errorf("version %d of item '%s' does not exist, the latest version is %d", *version, item.Title, item.Version)
+	}
+	return fmt.Errorf(
+		"version %d of item '%s' cannot be read as 1Password only provides the latest version %d of items, restore the version in 1Password to read it",
+		*version, item.Title, item.Version,
+	)
+}
+
+// returns the values of the given fields with labels matching the given glob pattern, keyed by their labels,
+// along with the labels shared by multiple fields, of which only the first field is part of the values.
+func itemFieldValues(itemFields []onepassword.ItemField, pattern string) (map[string]attr.Value, []string) {
+	fields := map[string]attr.Value{}
+	var duplicateLabels []string
+	for _, field := range itemFields {
+		if pattern != "" {
+			if matches, _ := filepath.Match(pattern, field.Title); !matches {
+				continue
+			}
+		}
+		if _, exists := fields[field.Title]; !exists {
+			fields[field.Title] = types.StringValue(field.Value)
+		} else if !slices.Contains(duplicateLabels, field.Title) {
+			duplicateLabels = append(duplicateLabels, field.Title)
+		}
+	}
+	return fields, duplicateLabels
+}
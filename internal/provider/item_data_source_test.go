@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestItemFieldValues(t *testing.T) {
+	tls := "tls"
+	itemFields := []onepassword.ItemField{
+		{ID: "username", Title: "username", Value: "admin"},
+		{ID: "password", Title: "password", Value: "first"},
+		{ID: "tlspassword", Title: "password", Value: "second", SectionID: &tls},
+		{ID: "host", Title: "host", Value: "db.example.com"},
+	}
+	tests := []struct {
+		name           string
+		pattern        string
+		want           map[string]string
+		wantDuplicates []string
+	}{
+		{
+			name:           "duplicate label",
+			want:           map[string]string{"username": "admin", "password": "first", "host": "db.example.com"},
+			wantDuplicates: []string{"password"},
+		},
+		{
+			name:    "duplicate label not matching the pattern",
+			pattern: "h*",
+			want:    map[string]string{"host": "db.example.com"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, duplicates := itemFieldValues(itemFields, test.pattern)
+			if len(got) != len(test.want) {
+				t.Fatalf("itemFieldValues returned %v, want %v", got, test.want)
+			}
+			for label, value := range test.want {
+				if !got[label].Equal(types.StringValue(value)) {
+					t.Errorf("itemFieldValues returned %v for %q, want %q", got[label], label, value)
+				}
+			}
+			if !slices.Equal(duplicates, test.wantDuplicates) {
+				t.Errorf("itemFieldValues reported the duplicate labels %v, want %v", duplicates, test.wantDuplicates)
+			}
+		})
+	}
+}
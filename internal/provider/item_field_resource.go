@@ -0,0 +1,433 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &itemFieldResource{}
+	_ resource.ResourceWithConfigure  = &itemFieldResource{}
+	_ resource.ResourceWithModifyPlan = &itemFieldResource{}
+)
+
+// Behaviors of the item field resource on destroy.
+const (
+	onDestroyKeep  = "keep"
+	onDestroyClear = "clear"
+)
+
+func NewItemFieldResource() resource.Resource {
+	return &itemFieldResource{}
+}
+
+type itemFieldResource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemFieldResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Vault     types.String `tfsdk:"vault"`
+	VaultID   types.String `tfsdk:"vault_id"`
+	Item      types.String `tfsdk:"item"`
+	ItemID    types.String `tfsdk:"item_id"`
+	Section   types.String `tfsdk:"section"`
+	Field     types.String `tfsdk:"field"`
+	Type      types.String `tfsdk:"type"`
+	Value     types.String `tfsdk:"value"`
+	OnDestroy types.String `tfsdk:"on_destroy"`
+}
+
+func (r *itemFieldResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.resolver = resolver
+}
+
+func (r *itemFieldResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_field"
+}
+
+func (r *itemFieldResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets the value of a single field of an existing 1Password item, e.g. to write back a rotated token, " +
+			"creating the field if the item does not have it yet. All other fields of the item are left untouched.<br>The service account needs write access to the vault.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item. Changing the vault replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault containing the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the existing item. Changing the item replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"section": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The title or ID of the existing section containing the field. Changing the section replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"field": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The label or ID of the field. Changing the field replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(onepassword.ItemFieldTypeConcealed)),
+				MarkdownDescription: "The type of the field, e.g. `Concealed` or `Text`. Defaults to `Concealed`, as fields rotated by terraform usually hold secrets.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(itemFieldTypes...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The value of the field.",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(onDestroyKeep),
+				MarkdownDescription: "What to do with the field when the resource is destroyed, either `keep` to leave the field and its last value in the item or `clear` to empty its value. Defaults to `keep`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyKeep, onDestroyClear),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects any change if the provider is read-only.
+func (r *itemFieldResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(r.resolver, req, &resp.Diagnostics)
+}
+
+func (r *itemFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan itemFieldResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItem(ctx, plan.Vault.ValueString(), plan.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	index, err := findItemField(item, plan.Section.ValueString(), plan.Field.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to set field",
+			err.Error(),
+		)
+		return
+	}
+	if index < 0 {
+		field, err := newItemField(item, plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to set field",
+				err.Error(),
+			)
+			return
+		}
+		item.Fields = append(item.Fields, field)
+		index = len(item.Fields) - 1
+	}
+	item.Fields[index].FieldType = onepassword.ItemFieldType(plan.Type.ValueString())
+	item.Fields[index].Value = plan.Value.ValueString()
+
+	updatedItem, err := r.resolver.putItem(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to set field",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	// 1Password may assign another ID to new fields, so the field is looked up again in the updated item
+	fieldId := item.Fields[index].ID
+	if findItemFieldById(updatedItem, fieldId) < 0 {
+		if updatedIndex, err := findItemField(updatedItem, plan.Section.ValueString(), plan.Field.ValueString()); err == nil && updatedIndex >= 0 {
+			fieldId = updatedItem.Fields[updatedIndex].ID
+		}
+	}
+
+	plan.ID = types.StringValue(fieldId)
+	plan.VaultID = types.StringValue(item.VaultID)
+	plan.ItemID = types.StringValue(item.ID)
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state itemFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// the item was deleted outside of terraform, so the field cannot be set anymore
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	index := findItemFieldById(item, state.ID.ValueString())
+	if index < 0 {
+		// the field was removed outside of terraform and needs to be created again
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Type = types.StringValue(string(item.Fields[index].FieldType))
+	state.Value = types.StringValue(item.Fields[index].Value)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state itemFieldResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// fetch the current item to keep all other fields unchanged
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update field",
+			err.Error(),
+		)
+		return
+	}
+
+	index := findItemFieldById(item, state.ID.ValueString())
+	if index < 0 {
+		resp.Diagnostics.AddError(
+			"Unable to update field",
+			fmt.Sprintf("field '%s' no longer exists in item '%s'", state.Field.ValueString(), item.Title),
+		)
+		return
+	}
+	item.Fields[index].FieldType = onepassword.ItemFieldType(plan.Type.ValueString())
+	item.Fields[index].Value = plan.Value.ValueString()
+
+	if _, err := r.resolver.putItem(ctx, item); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update field",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VaultID = state.VaultID
+	plan.ItemID = state.ItemID
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state itemFieldResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.ValueString() != onDestroyClear {
+		tflog.Debug(ctx, "Keeping field of item on destroy", map[string]interface{}{"item_id": state.ItemID.ValueString(), "field_id": state.ID.ValueString()})
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to clear field",
+			err.Error(),
+		)
+		return
+	}
+
+	index := findItemFieldById(item, state.ID.ValueString())
+	if index < 0 {
+		return
+	}
+	item.Fields[index].Value = ""
+
+	if _, err := r.resolver.putItem(ctx, item); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to clear field",
+			writeErrorDetail(err, state.Vault.ValueString()),
+		)
+		return
+	}
+}
+
+// searches the fields of the given item, matching by given field label or ID
+// and by the title or ID of the section containing the field, if a section is given
+// returns the index of the field or -1 if there is none, and an error object if multiple fields match.
+func findItemField(item onepassword.Item, sectionName string, fieldName string) (int, error) {
+	index := -1
+	var fieldIds []string
+	for i, field := range item.Fields {
+		if field.Title != fieldName && field.ID != fieldName {
+			continue
+		}
+		if sectionName != "" && (field.SectionID == nil || !sectionMatches(item, *field.SectionID, sectionName)) {
+			continue
+		}
+		index = i
+		fieldIds = append(fieldIds, fmt.Sprintf("'%s'", field.ID))
+	}
+	if len(fieldIds) > 1 {
+		return -1, fmt.Errorf(
+			"field '%s' matches the fields %s of item '%s', specify the section or use the field ID instead",
+			fieldName, strings.Join(fieldIds, ", "), item.Title,
+		)
+	}
+	return index, nil
+}
+
+// returns the index of the field with the given ID in the given item, or -1 if the item has no such field.
+func findItemFieldById(item onepassword.Item, fieldId string) int {
+	for i, field := range item.Fields {
+		if field.ID == fieldId {
+			return i
+		}
+	}
+	return -1
+}
+
+// creates a new field labeled like the configured field, placed in the configured section of the given item, if any,
+// returning the field and nil, or an empty field and an error object if the item has no such section.
+func newItemField(item onepassword.Item, plan itemFieldResourceModel) (onepassword.ItemField, error) {
+	field := onepassword.ItemField{
+		ID:    newItemFieldId(),
+		Title: plan.Field.ValueString(),
+	}
+	sectionName := plan.Section.ValueString()
+	if sectionName == "" {
+		return field, nil
+	}
+	for _, section := range item.Sections {
+		if section.ID == sectionName || section.Title == sectionName {
+			field.SectionID = &section.ID
+			return field, nil
+		}
+	}
+	return onepassword.ItemField{}, fmt.Errorf("section '%s' not found in item '%s'", sectionName, item.Title)
+}
+
+// returns a random field ID in the format 1Password uses for the IDs of custom fields,
+// as field labels may contain characters which are not valid in field IDs.
+func newItemFieldId() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	id := make([]byte, 26)
+	for i := range id {
+		id[i] = alphabet[rand.IntN(len(alphabet))]
+	}
+	return string(id)
+}
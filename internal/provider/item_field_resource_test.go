@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewItemField(t *testing.T) {
+	item := onepassword.Item{
+		Title:    "Service",
+		Sections: []onepassword.ItemSection{{ID: testId("tls"), Title: "TLS"}},
+	}
+	tests := []struct {
+		name        string
+		section     string
+		wantSection string
+		wantErr     bool
+	}{
+		{name: "without section"},
+		{name: "section by title", section: "TLS", wantSection: testId("tls")},
+		{name: "section by id", section: testId("tls"), wantSection: testId("tls")},
+		{name: "unknown section", section: "Other", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := itemFieldResourceModel{Field: types.StringValue("API Token"), Section: types.StringValue(test.section)}
+			if test.section == "" {
+				plan.Section = types.StringNull()
+			}
+
+			field, err := newItemField(item, plan)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("newItemField error = %v, want error %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if field.Title != "API Token" {
+				t.Errorf("newItemField labeled the field %q, want %q", field.Title, "API Token")
+			}
+			if !onePasswordIdPattern.MatchString(field.ID) {
+				t.Errorf("newItemField assigned the ID %q, want a valid 1Password ID instead of the label", field.ID)
+			}
+			sectionId := ""
+			if field.SectionID != nil {
+				sectionId = *field.SectionID
+			}
+			if sectionId != test.wantSection {
+				t.Errorf("newItemField placed the field in section %q, want %q", sectionId, test.wantSection)
+			}
+		})
+	}
+}
+
+func TestNewItemFieldIdIsUnique(t *testing.T) {
+	if first, second := newItemFieldId(), newItemFieldId(); first == second {
+		t.Errorf("newItemFieldId returned %q twice", first)
+	}
+}
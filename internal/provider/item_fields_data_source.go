@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &itemFieldsDataSource{}
+	_ datasource.DataSourceWithConfigure = &itemFieldsDataSource{}
+)
+
+func NewItemFieldsDataSource() datasource.DataSource {
+	return &itemFieldsDataSource{}
+}
+
+type itemFieldsDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemFieldsDataSourceModel struct {
+	Vault    types.String     `tfsdk:"vault"`
+	Item     types.String     `tfsdk:"item"`
+	LookupBy types.String     `tfsdk:"lookup_by"`
+	ID       types.String     `tfsdk:"id"`
+	Fields   []itemFieldModel `tfsdk:"fields"`
+}
+
+type itemFieldModel struct {
+	ID      types.String `tfsdk:"id"`
+	Label   types.String `tfsdk:"label"`
+	Type    types.String `tfsdk:"type"`
+	Section types.String `tfsdk:"section"`
+	Value   types.String `tfsdk:"value"`
+}
+
+func (d *itemFieldsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *itemFieldsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_fields"
+}
+
+func (d *itemFieldsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all fields of an item with their labels, types and sections, e.g. to migrate items of unknown structure into terraform configuration.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"lookup_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How to interpret the `vault` and `item`, one of `auto`, `name` or `id`. Defaults to `auto`.<br>" +
+					"`auto` uses values looking like 1Password IDs as IDs and looks up all other values by title, `name` always looks up the values by title " +
+					"and `id` always uses the values as IDs without listing vaults or items.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookupByAuto, lookupByName, lookupById),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"fields": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The fields of the item, in the order shown in 1Password.<br>Empty if the item has no fields.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the field.",
+						},
+						"label": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The label of the field.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the field, e.g. `Text`, `Concealed` for passwords or `Totp` for one-time passwords.",
+						},
+						"section": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The title of the section containing the field, null if the field is not part of a section.",
+						},
+						"value": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "The value of the field.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *itemFieldsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state itemFieldsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.withLookupBy(state.LookupBy.ValueString()).getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	// always return a list, even if the item has no fields
+	state.Fields = []itemFieldModel{}
+	for _, field := range item.Fields {
+		state.Fields = append(state.Fields, itemFieldModel{
+			ID:      types.StringValue(field.ID),
+			Label:   types.StringValue(field.Title),
+			Type:    types.StringValue(string(field.FieldType)),
+			Section: sectionTitle(item, field.SectionID),
+			Value:   types.StringValue(field.Value),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// returns the title of the section of the given item with the given ID, falling back to the ID for sections without title,
+// or null if no section ID is given.
+func sectionTitle(item onepassword.Item, sectionId *string) types.String {
+	if sectionId == nil || *sectionId == "" {
+		return types.StringNull()
+	}
+	for _, section := range item.Sections {
+		if section.ID == *sectionId && section.Title != "" {
+			return types.StringValue(section.Title)
+		}
+	}
+	return types.StringValue(*sectionId)
+}
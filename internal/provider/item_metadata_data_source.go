@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &itemMetadataDataSource{}
+	_ datasource.DataSourceWithConfigure = &itemMetadataDataSource{}
+)
+
+func NewItemMetadataDataSource() datasource.DataSource {
+	return &itemMetadataDataSource{}
+}
+
+type itemMetadataDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemMetadataDataSourceModel struct {
+	Vault     types.String `tfsdk:"vault"`
+	Item      types.String `tfsdk:"item"`
+	LookupBy  types.String `tfsdk:"lookup_by"`
+	ID        types.String `tfsdk:"id"`
+	Title     types.String `tfsdk:"title"`
+	Category  types.String `tfsdk:"category"`
+	Tags      types.List   `tfsdk:"tags"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	Version   types.Int64  `tfsdk:"version"`
+}
+
+func (d *itemMetadataDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *itemMetadataDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_metadata"
+}
+
+func (d *itemMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the metadata of an item without exposing any of its field values, e.g. to detect secrets which have not been rotated for a long time.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"lookup_by": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How to interpret the `vault` and `item`, one of `auto`, `name` or `id`. Defaults to `auto`.<br>" +
+					"`auto` uses values looking like 1Password IDs as IDs and looks up all other values by title, `name` always looks up the values by title " +
+					"and `id` always uses the values as IDs without listing vaults or items.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookupByAuto, lookupByName, lookupById),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The title of the item.",
+			},
+			"category": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The category of the item, e.g. `Login`, `Password` or `Document`.",
+			},
+			"tags": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The tags of the item.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the item was created, in RFC 3339 format.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the item was last updated, in RFC 3339 format.",
+			},
+			"version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the item, incremented on each update.",
+			},
+		},
+	}
+}
+
+func (d *itemMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state itemMetadataDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.withLookupBy(state.LookupBy.ValueString()).getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	// items without tags should expose an empty list instead of null
+	itemTags := item.Tags
+	if itemTags == nil {
+		itemTags = []string{}
+	}
+
+	tags, diags := types.ListValueFrom(ctx, types.StringType, itemTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	state.Title = types.StringValue(item.Title)
+	state.Category = types.StringValue(string(item.Category))
+	state.Tags = tags
+	state.CreatedAt = types.StringValue(item.CreatedAt.Format(time.RFC3339))
+	state.UpdatedAt = types.StringValue(item.UpdatedAt.Format(time.RFC3339))
+	state.Version = types.Int64Value(int64(item.Version))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &itemResource{}
+	_ resource.ResourceWithConfigure  = &itemResource{}
+	_ resource.ResourceWithModifyPlan = &itemResource{}
+)
+
+// itemCategories lists the item categories which can be created by the item resource.
+var itemCategories = []string{
+	string(onepassword.ItemCategoryLogin),
+	string(onepassword.ItemCategorySecureNote),
+	string(onepassword.ItemCategoryCreditCard),
+	string(onepassword.ItemCategoryCryptoWallet),
+	string(onepassword.ItemCategoryIdentity),
+	string(onepassword.ItemCategoryPassword),
+	string(onepassword.ItemCategoryAPICredentials),
+	string(onepassword.ItemCategoryBankAccount),
+	string(onepassword.ItemCategoryDatabase),
+	string(onepassword.ItemCategoryDriverLicense),
+	string(onepassword.ItemCategoryEmail),
+	string(onepassword.ItemCategoryMedicalRecord),
+	string(onepassword.ItemCategoryMembership),
+	string(onepassword.ItemCategoryOutdoorLicense),
+	string(onepassword.ItemCategoryPassport),
+	string(onepassword.ItemCategoryRewards),
+	string(onepassword.ItemCategoryRouter),
+	string(onepassword.ItemCategoryServer),
+	string(onepassword.ItemCategorySocialSecurityNumber),
+	string(onepassword.ItemCategorySoftwareLicense),
+}
+
+// itemFieldTypes lists the field types which can be set by the item resource.
+var itemFieldTypes = []string{
+	string(onepassword.ItemFieldTypeText),
+	string(onepassword.ItemFieldTypeConcealed),
+	string(onepassword.ItemFieldTypeURL),
+	string(onepassword.ItemFieldTypeEmail),
+	string(onepassword.ItemFieldTypePhone),
+	string(onepassword.ItemFieldTypeTOTP),
+	string(onepassword.ItemFieldTypeDate),
+	string(onepassword.ItemFieldTypeMonthYear),
+}
+
+func NewItemResource() resource.Resource {
+	return &itemResource{}
+}
+
+type itemResource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemResourceModel struct {
+	ID       types.String             `tfsdk:"id"`
+	Vault    types.String             `tfsdk:"vault"`
+	VaultID  types.String             `tfsdk:"vault_id"`
+	Title    types.String             `tfsdk:"title"`
+	Category types.String             `tfsdk:"category"`
+	Fields   []itemResourceFieldModel `tfsdk:"fields"`
+}
+
+type itemResourceFieldModel struct {
+	Label types.String `tfsdk:"label"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (r *itemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.resolver = resolver
+}
+
+func (r *itemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item"
+}
+
+func (r *itemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates and manages a 1Password item.<br>The service account needs write access to the vault.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault to create the item in. Changing the vault recreates the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault containing the item.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title of the item.",
+			},
+			"category": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(onepassword.ItemCategoryLogin)),
+				MarkdownDescription: "The category of the item, e.g. `Login`, `Password` or `ApiCredentials`. Defaults to `Login`. Changing the category recreates the item.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(itemCategories...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fields": schema.SetNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "The fields of the item. Field labels must be unique within the item. Fields not listed here, like the built-in fields 1Password adds to the item, are left untouched.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The label of the field.",
+						},
+						"type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(string(onepassword.ItemFieldTypeText)),
+							MarkdownDescription: "The type of the field, e.g. `Text` or `Concealed` for passwords. Defaults to `Text`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(itemFieldTypes...),
+							},
+						},
+						"value": schema.StringAttribute{
+							Required:            true,
+							Sensitive:           true,
+							MarkdownDescription: "The value of the field.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects any change if the provider is read-only.
+func (r *itemResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(r.resolver, req, &resp.Diagnostics)
+}
+
+func (r *itemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan itemResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(validateItemFields(plan.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaultId, err := r.resolver.getVaultId(ctx, plan.Vault.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create item",
+			err.Error(),
+		)
+		return
+	}
+
+	item, err := r.resolver.createItem(ctx, onepassword.ItemCreateParams{
+		VaultID:  vaultId,
+		Title:    plan.Title.ValueString(),
+		Category: onepassword.ItemCategory(plan.Category.ValueString()),
+		Fields:   toItemFields(plan.Fields, nil, nil),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create item",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(item.ID)
+	plan.VaultID = types.StringValue(item.VaultID)
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state itemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// the item was deleted outside of terraform and needs to be recreated
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Title = types.StringValue(item.Title)
+	state.Category = types.StringValue(string(item.Category))
+	state.Fields = fromItemFields(item.Fields, state.Fields)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state itemResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(validateItemFields(plan.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// fetch the current item to keep everything not managed by this resource, like sections, built-in fields and files
+	item, err := r.resolver.getItemById(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update item",
+			err.Error(),
+		)
+		return
+	}
+
+	item.Title = plan.Title.ValueString()
+	item.Fields = toItemFields(plan.Fields, state.Fields, item.Fields)
+
+	if _, err := r.resolver.putItem(ctx, item); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update item",
+			writeErrorDetail(err, plan.Vault.ValueString()),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VaultID = state.VaultID
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *itemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state itemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.resolver.deleteItem(ctx, state.VaultID.ValueString(), state.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Unable to delete item",
+			writeErrorDetail(err, state.Vault.ValueString()),
+		)
+		return
+	}
+}
+
+// validates that the labels of the given fields are unique, as fields are matched by their labels.
+func validateItemFields(fields []itemResourceFieldModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	labels := map[string]bool{}
+	for _, field := range fields {
+		label := field.Label.ValueString()
+		if labels[label] {
+			diags.AddAttributeError(
+				path.Root("fields"),
+				"Duplicate Field Label",
+				fmt.Sprintf("The label '%s' is used by multiple fields, but field labels must be unique within the item.", label),
+			)
+		}
+		labels[label] = true
+	}
+	return diags
+}
+
+// merges the given field models into the existing item fields, matching them by label.
+// Existing fields with a configured label are updated in place, keeping their ID and section,
+// fields only part of the prior state are removed and all other fields, like built-in fields
+// such as the username or the notes, are kept untouched.
+func toItemFields(fields []itemResourceFieldModel, priorFields []itemResourceFieldModel, existingFields []onepassword.ItemField) []onepassword.ItemField {
+	configured := map[string]itemResourceFieldModel{}
+	for _, field := range fields {
+		configured[field.Label.ValueString()] = field
+	}
+	prior := map[string]bool{}
+	for _, priorField := range priorFields {
+		prior[priorField.Label.ValueString()] = true
+	}
+
+	itemFields := make([]onepassword.ItemField, 0, len(existingFields)+len(fields))
+	merged := map[string]bool{}
+	for _, existingField := range existingFields {
+		field, isConfigured := configured[existingField.Title]
+		switch {
+		case isConfigured && !merged[existingField.Title]:
+			existingField.FieldType = onepassword.ItemFieldType(field.Type.ValueString())
+			existingField.Value = field.Value.ValueString()
+			merged[existingField.Title] = true
+		case prior[existingField.Title] && !isConfigured:
+			// the field was removed from the configuration
+			continue
+		}
+		itemFields = append(itemFields, existingField)
+	}
+	for _, field := range fields {
+		label := field.Label.ValueString()
+		if merged[label] {
+			continue
+		}
+		itemFields = append(itemFields, onepassword.ItemField{
+			ID:        newItemFieldId(),
+			Title:     label,
+			FieldType: onepassword.ItemFieldType(field.Type.ValueString()),
+			Value:     field.Value.ValueString(),
+		})
+	}
+	return itemFields
+}
+
+// converts the item fields managed by the resource to field models, i.e. the fields with a label of the prior state.
+// The fields are null if they are null in the prior state, while an empty list of fields stays empty.
+func fromItemFields(itemFields []onepassword.ItemField, priorFields []itemResourceFieldModel) []itemResourceFieldModel {
+	if priorFields == nil {
+		return nil
+	}
+	priorLabels := map[string]bool{}
+	for _, priorField := range priorFields {
+		priorLabels[priorField.Label.ValueString()] = true
+	}
+
+	fields := []itemResourceFieldModel{}
+	read := map[string]bool{}
+	for _, itemField := range itemFields {
+		if !priorLabels[itemField.Title] || read[itemField.Title] {
+			continue
+		}
+		read[itemField.Title] = true
+		fields = append(fields, itemResourceFieldModel{
+			Label: types.StringValue(itemField.Title),
+			Type:  types.StringValue(string(itemField.FieldType)),
+			Value: types.StringValue(itemField.Value),
+		})
+	}
+	return fields
+}
+
+// returns the detail message for the given error of a write operation,
+// pointing out missing write access to the given vault if the error indicates missing permissions.
+func writeErrorDetail(err error, vault string) string {
+	if isPermissionError(err) {
+		return fmt.Sprintf("%s\n\nMake sure the service account has write access to the vault '%s'.", err.Error(), vault)
+	}
+	return err.Error()
+}
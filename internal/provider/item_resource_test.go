@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testFieldModel(label string, fieldType onepassword.ItemFieldType, value string) itemResourceFieldModel {
+	return itemResourceFieldModel{
+		Label: types.StringValue(label),
+		Type:  types.StringValue(string(fieldType)),
+		Value: types.StringValue(value),
+	}
+}
+
+func TestToItemFieldsKeepsUnmanagedFields(t *testing.T) {
+	section := "section"
+	existing := []onepassword.ItemField{
+		{ID: "username", Title: "username", FieldType: onepassword.ItemFieldTypeText, Value: "admin"},
+		{ID: "notesPlain", Title: "notesPlain", FieldType: onepassword.ItemFieldTypeText, Value: "some notes"},
+		{ID: "TOTP_1", Title: "one-time password", FieldType: onepassword.ItemFieldTypeTOTP, Value: "otpauth://totp/test"},
+		{ID: "abc", Title: "api key", FieldType: onepassword.ItemFieldTypeConcealed, Value: "old", SectionID: &section},
+		{ID: "def", Title: "removed", FieldType: onepassword.ItemFieldTypeText, Value: "gone"},
+	}
+	prior := []itemResourceFieldModel{
+		testFieldModel("api key", onepassword.ItemFieldTypeConcealed, "old"),
+		testFieldModel("removed", onepassword.ItemFieldTypeText, "gone"),
+	}
+	planned := []itemResourceFieldModel{
+		testFieldModel("api key", onepassword.ItemFieldTypeConcealed, "new"),
+		testFieldModel("region", onepassword.ItemFieldTypeText, "eu"),
+	}
+
+	got := toItemFields(planned, prior, existing)
+
+	byTitle := map[string]onepassword.ItemField{}
+	for _, field := range got {
+		byTitle[field.Title] = field
+	}
+	if len(got) != 5 {
+		t.Fatalf("toItemFields returned %d fields, want 5: %+v", len(got), got)
+	}
+	for _, title := range []string{"username", "notesPlain", "one-time password"} {
+		if _, ok := byTitle[title]; !ok {
+			t.Errorf("toItemFields dropped the unmanaged field %q", title)
+		}
+	}
+	if _, ok := byTitle["removed"]; ok {
+		t.Error("toItemFields kept the field removed from the configuration")
+	}
+	apiKey := byTitle["api key"]
+	if apiKey.Value != "new" || apiKey.ID != "abc" || apiKey.SectionID == nil || *apiKey.SectionID != "section" {
+		t.Errorf("toItemFields updated the field to %+v, want the new value keeping its ID and section", apiKey)
+	}
+	if region := byTitle["region"]; region.Value != "eu" {
+		t.Errorf("toItemFields added the field %+v, want the value eu", region)
+	}
+}
+
+func TestFromItemFields(t *testing.T) {
+	itemFields := []onepassword.ItemField{
+		{ID: "username", Title: "username", FieldType: onepassword.ItemFieldTypeText, Value: "admin"},
+		{ID: "abc", Title: "api key", FieldType: onepassword.ItemFieldTypeConcealed, Value: "secret"},
+		{ID: "password", Title: "password", FieldType: onepassword.ItemFieldTypeConcealed, Value: ""},
+	}
+	tests := []struct {
+		name       string
+		prior      []itemResourceFieldModel
+		wantNil    bool
+		wantLabels []string
+	}{
+		{name: "null fields stay null", prior: nil, wantNil: true},
+		{name: "empty fields stay empty", prior: []itemResourceFieldModel{}},
+		{
+			name:       "only managed fields",
+			prior:      []itemResourceFieldModel{testFieldModel("api key", onepassword.ItemFieldTypeConcealed, "secret")},
+			wantLabels: []string{"api key"},
+		},
+		{
+			name:       "managed empty field",
+			prior:      []itemResourceFieldModel{testFieldModel("password", onepassword.ItemFieldTypeConcealed, "")},
+			wantLabels: []string{"password"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fromItemFields(itemFields, test.prior)
+			if (got == nil) != test.wantNil {
+				t.Fatalf("fromItemFields returned nil = %v, want %v", got == nil, test.wantNil)
+			}
+			if len(got) != len(test.wantLabels) {
+				t.Fatalf("fromItemFields returned %d fields, want %v", len(got), test.wantLabels)
+			}
+			for i, label := range test.wantLabels {
+				if got[i].Label.ValueString() != label {
+					t.Errorf("fromItemFields field %d has the label %q, want %q", i, got[i].Label.ValueString(), label)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &itemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &itemsDataSource{}
+)
+
+func NewItemsDataSource() datasource.DataSource {
+	return &itemsDataSource{}
+}
+
+type itemsDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type itemsDataSourceModel struct {
+	Vault types.String        `tfsdk:"vault"`
+	Items []itemOverviewModel `tfsdk:"items"`
+}
+
+type itemOverviewModel struct {
+	ID       types.String `tfsdk:"id"`
+	Title    types.String `tfsdk:"title"`
+	Category types.String `tfsdk:"category"`
+}
+
+func (d *itemsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *itemsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_items"
+}
+
+func (d *itemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all items of a vault.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault to list the items of.",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The items stored in the vault.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the item.",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The title of the item.",
+						},
+						"category": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The category of the item, e.g. `Login`, `Password` or `Document`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *itemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state itemsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaultId, err := d.resolver.getVaultId(ctx, state.Vault.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read vault",
+			err.Error(),
+		)
+		return
+	}
+
+	items, err := d.resolver.listItems(ctx, vaultId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list items",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Items = []itemOverviewModel{}
+	for _, item := range items {
+		state.Items = append(state.Items, itemOverviewModel{
+			ID:       types.StringValue(item.ID),
+			Title:    types.StringValue(item.Title),
+			Category: types.StringValue(string(item.Category)),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
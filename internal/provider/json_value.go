@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// decodes the given JSON document into a terraform value, like the jsondecode function of terraform does.
+// Objects become objects, arrays become tuples and null becomes a null string, as null values need a concrete type in the state.
+func decodeJSONValue(ctx context.Context, content []byte) (attr.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// numbers are kept as they are instead of losing precision as float64
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after the JSON document")
+	}
+	return jsonToValue(ctx, document)
+}
+
+// converts the given value decoded by encoding/json into a terraform value.
+func jsonToValue(ctx context.Context, value any) (attr.Value, error) {
+	switch value := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case bool:
+		return types.BoolValue(value), nil
+	case string:
+		return types.StringValue(value), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(value.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(number), nil
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(value))
+		elements := make([]attr.Value, 0, len(value))
+		for _, element := range value {
+			converted, err := jsonToValue(ctx, element)
+			if err != nil {
+				return nil, err
+			}
+			elementTypes = append(elementTypes, converted.Type(ctx))
+			elements = append(elements, converted)
+		}
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to convert JSON array: %v", diags)
+		}
+		return tuple, nil
+	case map[string]any:
+		attributeTypes := make(map[string]attr.Type, len(value))
+		attributes := make(map[string]attr.Value, len(value))
+		for name, attribute := range value {
+			converted, err := jsonToValue(ctx, attribute)
+			if err != nil {
+				return nil, err
+			}
+			attributeTypes[name] = converted.Type(ctx)
+			attributes[name] = converted
+		}
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to convert JSON object: %v", diags)
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("unsupported JSON value of type %T", value)
+}
@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &localFileResource{}
+	_ resource.ResourceWithConfigure  = &localFileResource{}
+	_ resource.ResourceWithModifyPlan = &localFileResource{}
+)
+
+// defaultFilePermission only allows the owner to read and write files written by the local file resource.
+const defaultFilePermission = "0600"
+
+func NewLocalFileResource() resource.Resource {
+	return &localFileResource{}
+}
+
+type localFileResource struct {
+	resolver *secretReferenceResolver
+}
+
+type localFileResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Reference      types.String `tfsdk:"reference"`
+	Destination    types.String `tfsdk:"destination"`
+	FilePermission types.String `tfsdk:"file_permission"`
+	ContentHash    types.String `tfsdk:"content_hash"`
+}
+
+func (r *localFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.resolver = resolver
+}
+
+func (r *localFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_file"
+}
+
+func (r *localFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Writes the resolved value of a secret reference, e.g. a file attachment, to a local file.<br>" +
+			"Only the hash of the content is stored in the terraform state. The file is rewritten if the content in 1Password changes or the local file is modified.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The path of the written file.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reference": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.String{
+					secretReferenceValidator{},
+				},
+			},
+			"destination": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The path of the file to write. Missing parent directories are created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_permission": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultFilePermission),
+				MarkdownDescription: "The permission of the written file in octal notation. Defaults to `" + defaultFilePermission + "`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^0?[0-7]{3}$`), "must be a file permission in octal notation like 0600"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the file content, in hex encoding.",
+			},
+		},
+	}
+}
+
+// ModifyPlan resolves the secret reference to plan the hash of its current content,
+// so the file is rewritten if the content in 1Password differs from the local file.
+func (r *localFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.resolver == nil {
+		return
+	}
+
+	var plan localFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Reference.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+		return
+	}
+
+	content, err := r.resolveContent(ctx, plan.Reference.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read secret reference",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringValue(contentHash(content)))...)
+}
+
+func (r *localFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan localFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *localFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state localFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := os.ReadFile(state.Destination.ValueString())
+	if errors.Is(err, fs.ErrNotExist) {
+		// the file was removed outside of terraform and needs to be written again
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read local file",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ContentHash = types.StringValue(contentHash(content))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *localFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan localFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *localFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state localFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(state.Destination.ValueString()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to delete local file",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// resolves the given secret reference into the raw content to write.
+func (r *localFileResource) resolveContent(ctx context.Context, reference string) ([]byte, error) {
+	resolved, err := r.resolver.resolve(ctx, reference, fileEncodingRaw)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resolved.value), nil
+}
+
+// resolves the secret reference of the given model and writes its content to the destination,
+// updating the computed attributes of the model.
+func (r *localFileResource) write(ctx context.Context, plan *localFileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	content, err := r.resolveContent(ctx, plan.Reference.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to read secret reference",
+			err.Error(),
+		)
+		return diags
+	}
+
+	permission, err := strconv.ParseUint(plan.FilePermission.ValueString(), 8, 32)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("file_permission"),
+			"Invalid File Permission",
+			err.Error(),
+		)
+		return diags
+	}
+
+	destination := plan.Destination.ValueString()
+	if err := os.MkdirAll(filepath.Dir(destination), 0o700); err != nil {
+		diags.AddError(
+			"Unable to create directory of local file",
+			err.Error(),
+		)
+		return diags
+	}
+	if err := os.WriteFile(destination, content, os.FileMode(permission)); err != nil {
+		diags.AddError(
+			"Unable to write local file",
+			err.Error(),
+		)
+		return diags
+	}
+	// WriteFile only applies the permission to new files
+	if err := os.Chmod(destination, os.FileMode(permission)); err != nil {
+		diags.AddError(
+			"Unable to write local file",
+			err.Error(),
+		)
+		return diags
+	}
+
+	plan.ID = types.StringValue(destination)
+	plan.ContentHash = types.StringValue(contentHash(content))
+	return diags
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &loginDataSource{}
+	_ datasource.DataSourceWithConfigure = &loginDataSource{}
+)
+
+// IDs of the built-in username and password fields of login items.
+const (
+	loginUsernameFieldId = "username"
+	loginPasswordFieldId = "password"
+)
+
+func NewLoginDataSource() datasource.DataSource {
+	return &loginDataSource{}
+}
+
+type loginDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type loginDataSourceModel struct {
+	Vault    types.String `tfsdk:"vault"`
+	Item     types.String `tfsdk:"item"`
+	ID       types.String `tfsdk:"id"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Urls     types.List   `tfsdk:"urls"`
+}
+
+func (d *loginDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *loginDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_login"
+}
+
+func (d *loginDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the username, password and websites of a login item at once.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the login item.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the item.",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username of the login, null if the item has no username field.",
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The password of the login, null if the item has no password field.",
+			},
+			"urls": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The URLs of the websites of the login, in the order shown in 1Password.",
+			},
+		},
+	}
+}
+
+func (d *loginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state loginDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+	if item.Category != onepassword.ItemCategoryLogin {
+		resp.Diagnostics.AddError(
+			"Unable to read login",
+			fmt.Sprintf("item '%s' is not a login but of category %s", item.Title, item.Category),
+		)
+		return
+	}
+
+	// websites are exposed as an empty list instead of null if the login has none
+	urls := make([]string, 0, len(item.Websites))
+	for _, website := range item.Websites {
+		urls = append(urls, website.URL)
+	}
+
+	urlList, diags := types.ListValueFrom(ctx, types.StringType, urls)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(item.ID)
+	state.Username = types.StringNull()
+	state.Password = types.StringNull()
+	for _, field := range item.Fields {
+		switch field.ID {
+		case loginUsernameFieldId:
+			state.Username = types.StringValue(field.Value)
+		case loginPasswordFieldId:
+			state.Password = types.StringValue(field.Value)
+		}
+	}
+	state.Urls = urlList
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
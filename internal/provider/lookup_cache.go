@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// lookupCache caches the vault and item listings used to look up vault and item IDs by their names,
+// so resolving many references of the same vault does not list the vault contents over and over again.
+type lookupCache struct {
+	vaults cachedListing[[]onepassword.VaultOverview]
+
+	itemsMutex sync.Mutex
+	items      map[string]*cachedListing[[]onepassword.ItemOverview]
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		items: map[string]*cachedListing[[]onepassword.ItemOverview]{},
+	}
+}
+
+// itemsOf returns the cached item listing of the vault with the given ID.
+func (c *lookupCache) itemsOf(vaultId string) *cachedListing[[]onepassword.ItemOverview] {
+	c.itemsMutex.Lock()
+	defer c.itemsMutex.Unlock()
+
+	listing, ok := c.items[vaultId]
+	if !ok {
+		listing = &cachedListing[[]onepassword.ItemOverview]{}
+		c.items[vaultId] = listing
+	}
+	return listing
+}
+
+// invalidateItems drops the cached item listing of the vault with the given ID,
+// so items created, renamed or deleted by this provider are picked up by subsequent lookups.
+func (c *lookupCache) invalidateItems(vaultId string) {
+	c.itemsMutex.Lock()
+	defer c.itemsMutex.Unlock()
+
+	delete(c.items, vaultId)
+}
+
+// cachedListing holds a lazily loaded listing, loading it at most once even if requested concurrently.
+// Failed loads are not cached, so transient errors do not stick.
+type cachedListing[T any] struct {
+	mutex  sync.Mutex
+	loaded bool
+	value  T
+}
+
+// get returns the cached listing, loading it with the given function if not loaded yet.
+func (c *cachedListing[T]) get(load func() (T, error)) (T, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.loaded {
+		return c.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	c.value = value
+	c.loaded = true
+	return value, nil
+}
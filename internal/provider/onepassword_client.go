@@ -0,0 +1,386 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// errRequestTimeout is returned if a call to 1Password exceeds the configured request timeout.
+var errRequestTimeout = errors.New("the request to 1Password timed out")
+
+// callWithTimeout invokes the given SDK call using a context bounded by the given timeout,
+// where a timeout of zero applies no additional deadline.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	callCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, err := call(callCtx)
+	// deadlines of the given context, like the read timeout, are reported by the caller
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return result, fmt.Errorf("%w after %s, this indicates a network issue rather than an authentication failure: %w", errRequestTimeout, timeout, err)
+	}
+	return result, err
+}
+
+// errReadTimeout is the cause of contexts cancelled by the configured read timeout.
+var errReadTimeout = errors.New("reading from 1Password timed out")
+
+// withReadTimeout derives a context bounded by the configured read timeout, which limits all calls of a single read together,
+// e.g. looking up the vault and item and reading the file of a file reference. A read timeout of zero applies no additional deadline.
+func (r *secretReferenceResolver) withReadTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.readTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, r.readTimeout, fmt.Errorf("%w after %s", errReadTimeout, r.readTimeout))
+}
+
+// withReadProgress adds the given description of the step in progress to the given error if the read timeout of the given context fired,
+// telling how far a read got before timing out. Other errors and errors already describing the progress are returned unchanged.
+func withReadProgress(ctx context.Context, err error, progress string) error {
+	cause := context.Cause(ctx)
+	if err == nil || !errors.Is(cause, errReadTimeout) || errors.Is(err, errReadTimeout) {
+		return err
+	}
+	return fmt.Errorf("%w while %s: %w", cause, progress, err)
+}
+
+// isTransientError reports whether the given error is caused by a transient failure,
+// as opposed to e.g. authentication or not found errors which will fail again on retry.
+// Errors are classified by their type and status code only, as messages may contain arbitrary digits like IDs, sizes or ports.
+// The SDK and the CLI report failures other than rate limiting of the SDK as plain messages, which are therefore never retried.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if isRateLimitError(err) || errors.Is(err, errRequestTimeout) {
+		return true
+	}
+
+	switch connectStatusCode(err) {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	// network failures of the Connect client, like timeouts, resets or failing DNS lookups
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// errTokenRevoked is returned if 1Password rejects the token after the provider has been configured,
+// e.g. because it expired or was revoked while a long apply was running.
+var errTokenRevoked = errors.New("the token has expired or has been revoked")
+
+// isTokenRevokedError reports whether the given error is caused by a token which is no longer accepted,
+// which is never worth retrying unlike transient errors.
+// Connect servers report rejected tokens by their status code, while the SDK reports them as plain messages
+// recognized by the fragments of tokenRejectedErrorIndicators.
+func isTokenRevokedError(err error) bool {
+	if errors.Is(err, errTokenRevoked) || connectStatusCode(err) == http.StatusUnauthorized {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, indicator := range tokenRejectedErrorIndicators {
+		if strings.Contains(message, indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the summary of the diagnostic for the given error of reading a secret reference,
+// pointing out tokens which are no longer accepted, as they require rotating the token rather than fixing the reference.
+func readErrorSummary(err error) string {
+	if errors.Is(err, errTokenRevoked) {
+		return "Token Expired or Revoked"
+	}
+	return "Unable to read secret reference"
+}
+
+// notFoundErrorIndicators are lower case message fragments of errors returned if a vault, item or field does not exist (anymore).
+var notFoundErrorIndicators = []string{
+	"404",
+	"not found",
+	"does not exist",
+	"doesn't exist",
+	"cannot be found",
+	// messages of the SDK for secret references pointing to missing vaults, items or sections
+	"no vault matched",
+	"no item matched",
+	"no section matched",
+}
+
+// isNotFoundError reports whether the given error is caused by a missing vault, item or field.
+func isNotFoundError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, indicator := range notFoundErrorIndicators {
+		if strings.Contains(message, indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionErrorIndicators are lower case message fragments of errors returned if the token lacks access to a vault.
+var permissionErrorIndicators = []string{
+	"403",
+	"forbidden",
+	"permission",
+	"not authorized",
+	"unauthorized",
+	"access denied",
+}
+
+// isPermissionError reports whether the given error is caused by missing permissions of the token.
+// Rejected tokens are reported as such instead, even though their messages may read like missing permissions.
+func isPermissionError(err error) bool {
+	if isTokenRevokedError(err) {
+		return false
+	}
+	if connectStatusCode(err) == http.StatusForbidden {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, indicator := range permissionErrorIndicators {
+		if strings.Contains(message, indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+// call invokes the given SDK call, bounding each attempt by the configured request timeout
+// and retrying transient failures with exponential backoff until the retries are exhausted or the context is done.
+// No call is made at all once the context is done, so cancelled operations spanning multiple calls return promptly.
+func call[T any](ctx context.Context, r *secretReferenceResolver, sdkCall func(context.Context) (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			var empty T
+			return empty, err
+		}
+
+		result, err := callWithTimeout(ctx, r.requestTimeout, sdkCall)
+		err = r.redactor.wrap(err)
+		if err == nil {
+			return result, nil
+		}
+
+		// a token rejected by 1Password is rejected again on every retry, so the caller is told to rotate it right away
+		if isTokenRevokedError(err) {
+			return result, fmt.Errorf(
+				"%w. Create a new service account or Connect token, e.g. in the 1Password admin console, and run terraform again with the new token: %w",
+				errTokenRevoked, err,
+			)
+		}
+
+		// servers may ask to wait longer than the backoff before retrying rate limited requests
+		wait := r.retryBackoff << attempt
+		retryAfter := retryAfterHint(err)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if attempt >= r.maxRetries || !isTransientError(err) || retryAfter > maxRetryAfter {
+			if isRateLimitError(err) {
+				return result, withRateLimitGuidance(err, attempt)
+			}
+			return result, err
+		}
+
+		tflog.Debug(ctx, "Retrying call to 1Password after transient error", map[string]interface{}{"attempt": attempt + 1, "wait": wait.String(), "error": err.Error()})
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// resolves the given secret reference directly, e.g. using the SDK.
+func (r *secretReferenceResolver) resolveSecret(ctx context.Context, secretReference string) (string, error) {
+	value, err := call(ctx, r, func(ctx context.Context) (string, error) {
+		return r.lookup.Resolve(ctx, secretReference)
+	})
+	r.redactor.add(value)
+	return value, err
+}
+
+// lists all vaults accessible by the client, using the lookup cache if available.
+// The SDK and the CLI list all vaults at once, while the Connect client follows next page links, so the returned list always is complete.
+func (r *secretReferenceResolver) listVaults(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	list := func() ([]onepassword.VaultOverview, error) {
+		return call(ctx, r, func(ctx context.Context) ([]onepassword.VaultOverview, error) {
+			return r.lookup.ListVaults(ctx)
+		})
+	}
+	if r.cache == nil {
+		return list()
+	}
+	return r.cache.vaults.get(list)
+}
+
+// verifies that the token of the client works by listing the accessible vaults,
+// returning an error explaining whether the token was rejected or 1Password could not be reached.
+func (r *secretReferenceResolver) validateToken(ctx context.Context) error {
+	_, err := r.listVaults(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case isTransientError(err):
+		return fmt.Errorf("1Password could not be reached to validate the token: %w", err)
+	default:
+		return fmt.Errorf("the token is invalid or expired: %w", err)
+	}
+}
+
+// lists all items of the vault with the given ID, using the lookup cache if available.
+// Like vaults, large vaults are listed completely, following next page links when using Connect.
+func (r *secretReferenceResolver) listItems(ctx context.Context, vaultId string) ([]onepassword.ItemOverview, error) {
+	list := func() ([]onepassword.ItemOverview, error) {
+		return call(ctx, r, func(ctx context.Context) ([]onepassword.ItemOverview, error) {
+			return r.lookup.ListItems(ctx, vaultId)
+		})
+	}
+	if r.cache == nil {
+		return list()
+	}
+	return r.cache.itemsOf(vaultId).get(list)
+}
+
+// gets the details of the item with the given vault and item IDs.
+func (r *secretReferenceResolver) getItemById(ctx context.Context, vaultId string, itemId string) (onepassword.Item, error) {
+	item, err := call(ctx, r, func(ctx context.Context) (onepassword.Item, error) {
+		return r.lookup.GetItem(ctx, vaultId, itemId)
+	})
+	for _, field := range item.Fields {
+		if isSensitiveField(field) {
+			r.redactor.add(field.Value)
+		}
+	}
+	return item, err
+}
+
+// reports whether the value of the given field is sensitive and must not appear in error messages.
+func isSensitiveField(field onepassword.ItemField) bool {
+	switch field.FieldType {
+	case onepassword.ItemFieldTypeConcealed, onepassword.ItemFieldTypeSSHKey, onepassword.ItemFieldTypeTOTP, onepassword.ItemFieldTypeCreditCardNumber:
+		return true
+	}
+	return false
+}
+
+// reads the content of the given file attachment.
+func (r *secretReferenceResolver) readFile(ctx context.Context, vaultId string, itemId string, attributes onepassword.FileAttributes) ([]byte, error) {
+	return call(ctx, r, func(ctx context.Context) ([]byte, error) {
+		return r.lookup.ReadFile(ctx, vaultId, itemId, attributes)
+	})
+}
+
+// generates a random password of the given length using the password generator of the SDK,
+// which runs locally without calling 1Password.
+func (r *secretReferenceResolver) generatePassword(ctx context.Context, length uint32, digits bool, symbols bool) (string, error) {
+	recipe := onepassword.NewPasswordRecipeTypeVariantRandom(&onepassword.PasswordRecipeRandomInner{
+		Length:         length,
+		IncludeDigits:  digits,
+		IncludeSymbols: symbols,
+	})
+	response, err := call(ctx, r, func(ctx context.Context) (onepassword.GeneratePasswordResponse, error) {
+		return onepassword.Secrets.GeneratePassword(ctx, recipe)
+	})
+	r.redactor.add(response.Password)
+	return response.Password, err
+}
+
+// creates a new item, invalidating the cached item listing of its vault.
+func (r *secretReferenceResolver) createItem(ctx context.Context, params onepassword.ItemCreateParams) (onepassword.Item, error) {
+	if err := r.checkWritable(); err != nil {
+		return onepassword.Item{}, err
+	}
+	r.invalidateItems(params.VaultID)
+	return call(ctx, r, func(ctx context.Context) (onepassword.Item, error) {
+		return r.client.Items().Create(ctx, params)
+	})
+}
+
+// replaces the given item, invalidating the cached item listing of its vault.
+func (r *secretReferenceResolver) putItem(ctx context.Context, item onepassword.Item) (onepassword.Item, error) {
+	if err := r.checkWritable(); err != nil {
+		return onepassword.Item{}, err
+	}
+	r.invalidateItems(item.VaultID)
+	return call(ctx, r, func(ctx context.Context) (onepassword.Item, error) {
+		return r.client.Items().Put(ctx, item)
+	})
+}
+
+// deletes the item with the given vault and item IDs, invalidating the cached item listing of its vault.
+func (r *secretReferenceResolver) deleteItem(ctx context.Context, vaultId string, itemId string) error {
+	if err := r.checkWritable(); err != nil {
+		return err
+	}
+	r.invalidateItems(vaultId)
+	_, err := call(ctx, r, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, r.client.Items().Delete(ctx, vaultId, itemId)
+	})
+	return err
+}
+
+// drops the cached item listing of the vault with the given ID and all cached secrets, if caching is enabled.
+func (r *secretReferenceResolver) invalidateItems(vaultId string) {
+	if r.cache != nil {
+		r.cache.invalidateItems(vaultId)
+	}
+	if r.secrets != nil {
+		r.secrets.clear()
+	}
+}
+
+// drops the cached secret of the given reference, if secret caching is enabled,
+// e.g. once the referenced item became consistent after an update, so a value cached before the update is not returned.
+func (r *secretReferenceResolver) invalidateSecret(secretReference string) {
+	if r.secrets != nil {
+		r.secrets.invalidate(secretReference)
+	}
+}
+
+// attaches a new file to the given item, returning the updated item.
+func (r *secretReferenceResolver) attachFile(ctx context.Context, item onepassword.Item, params onepassword.FileCreateParams) (onepassword.Item, error) {
+	if err := r.checkWritable(); err != nil {
+		return onepassword.Item{}, err
+	}
+	return call(ctx, r, func(ctx context.Context) (onepassword.Item, error) {
+		return r.client.Items().Files().Attach(ctx, item, params)
+	})
+}
+
+// deletes the file stored in the given section and field of the given item, returning the updated item.
+func (r *secretReferenceResolver) deleteFile(ctx context.Context, item onepassword.Item, sectionId string, fieldId string) (onepassword.Item, error) {
+	if err := r.checkWritable(); err != nil {
+		return onepassword.Item{}, err
+	}
+	return call(ctx, r, func(ctx context.Context) (onepassword.Item, error) {
+		return r.client.Items().Files().Delete(ctx, item, sectionId, fieldId)
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "connect service unavailable", err: &connectStatusError{statusCode: http.StatusServiceUnavailable, status: "503 Service Unavailable"}, want: true},
+		{name: "connect bad gateway", err: &connectStatusError{statusCode: http.StatusBadGateway, status: "502 Bad Gateway"}, want: true},
+		{name: "connect rate limit", err: newRateLimitError(&connectStatusError{statusCode: http.StatusTooManyRequests}, http.Header{}), want: true},
+		{name: "wrapped connect server error", err: fmt.Errorf("listing vaults: %w", &connectStatusError{statusCode: http.StatusInternalServerError}), want: true},
+		{name: "connect not found", err: &connectStatusError{statusCode: http.StatusNotFound, status: "404 Not Found"}},
+		{name: "connect unauthorized", err: &connectStatusError{statusCode: http.StatusUnauthorized, status: "401 Unauthorized"}},
+		{name: "request timeout", err: fmt.Errorf("%w after 1s", errRequestTimeout), want: true},
+		{name: "network timeout", err: &net.OpError{Op: "dial", Err: &timeoutError{}}, want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, want: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "temporary dns failure", err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, want: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", IsNotFound: true}},
+		{name: "cancelled", err: context.Canceled},
+		{name: "message with status like digits", err: errors.New("file 'dump-503.sql' of item 'abc429def500' not found")},
+		{name: "message of sdk", err: errors.New("error resolving secret reference: service unavailable")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isTransientError(test.err); got != test.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+// timeoutError is a network error caused by a timeout.
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestCallRetriesTransientErrors(t *testing.T) {
+	lookup := newTestAccount()
+	lookup.errs = []error{
+		&connectStatusError{statusCode: http.StatusServiceUnavailable, status: "503 Service Unavailable"},
+		&connectStatusError{statusCode: http.StatusBadGateway, status: "502 Bad Gateway"},
+	}
+	resolver := newTestResolver(lookup)
+	resolver.maxRetries = 3
+	resolver.retryBackoff = time.Millisecond
+
+	vaults, err := resolver.listVaults(context.Background())
+	if err != nil {
+		t.Fatalf("listVaults failed despite retries: %v", err)
+	}
+	if len(vaults) != 3 {
+		t.Errorf("listVaults returned %d vaults, want 3", len(vaults))
+	}
+	if calls := lookup.callCount(); calls != 3 {
+		t.Errorf("listVaults made %d calls, want 3", calls)
+	}
+}
+
+func TestCallGivesUpAfterMaxRetries(t *testing.T) {
+	lookup := newTestAccount()
+	for range 3 {
+		lookup.errs = append(lookup.errs, &connectStatusError{statusCode: http.StatusServiceUnavailable, status: "503 Service Unavailable"})
+	}
+	resolver := newTestResolver(lookup)
+	resolver.maxRetries = 1
+	resolver.retryBackoff = time.Millisecond
+
+	if _, err := resolver.listVaults(context.Background()); connectStatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("listVaults error = %v, want the last transient error", err)
+	}
+	if calls := lookup.callCount(); calls != 2 {
+		t.Errorf("listVaults made %d calls, want 2", calls)
+	}
+}
+
+func TestCallDoesNotRetryPermanentErrors(t *testing.T) {
+	lookup := newTestAccount()
+	lookup.errs = []error{errors.New("vault 'backup-503' not found")}
+	resolver := newTestResolver(lookup)
+	resolver.maxRetries = 3
+	resolver.retryBackoff = time.Millisecond
+
+	if _, err := resolver.listVaults(context.Background()); err == nil {
+		t.Fatal("listVaults succeeded, want the permanent error")
+	}
+	if calls := lookup.callCount(); calls != 1 {
+		t.Errorf("listVaults made %d calls, want 1", calls)
+	}
+}
+
+func TestIsTokenRevokedError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "connect unauthorized", err: &connectStatusError{statusCode: http.StatusUnauthorized, status: "401 Unauthorized", message: "Invalid token signature"}, want: true},
+		{name: "wrapped connect unauthorized", err: fmt.Errorf("reading item: %w", &connectStatusError{statusCode: http.StatusUnauthorized}), want: true},
+		{name: "revoked token", err: fmt.Errorf("%w: rotate it", errTokenRevoked), want: true},
+		{name: "sdk unauthenticated", err: errors.New("error resolving secret reference: you are not authenticated"), want: true},
+		{name: "sdk bad token", err: errors.New("bad service account token, please rotate it: token has been deleted"), want: true},
+		{name: "sdk invalid throttle token", err: errors.New("service account token's throttle token is invalid. please create a new service account token: expired"), want: true},
+		{name: "connect forbidden", err: &connectStatusError{statusCode: http.StatusForbidden, status: "403 Forbidden"}},
+		{name: "expired certificate", err: errors.New("tls: failed to verify certificate: x509: certificate has expired or is not yet valid")},
+		{name: "item titled like revoked", err: errors.New("item 'revoked-keys' not found")},
+		{name: "message with status like digits", err: errors.New("vault 'team-401' not found")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isTokenRevokedError(test.err); got != test.want {
+				t.Errorf("isTokenRevokedError(%v) = %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCallDoesNotRetryRevokedTokens(t *testing.T) {
+	lookup := newTestAccount()
+	lookup.errs = []error{&connectStatusError{statusCode: http.StatusUnauthorized, status: "401 Unauthorized", message: "token has been revoked"}}
+	resolver := newTestResolver(lookup)
+	resolver.maxRetries = 3
+	resolver.retryBackoff = time.Millisecond
+
+	_, err := resolver.listVaults(context.Background())
+	if !errors.Is(err, errTokenRevoked) {
+		t.Fatalf("listVaults error = %v, want %v", err, errTokenRevoked)
+	}
+	if summary := readErrorSummary(err); summary != "Token Expired or Revoked" {
+		t.Errorf("readErrorSummary = %q, want the dedicated diagnostic", summary)
+	}
+	if isPermissionError(err) {
+		t.Error("isPermissionError = true, want revoked tokens not to be reported as missing permissions")
+	}
+	if calls := lookup.callCount(); calls != 1 {
+		t.Errorf("listVaults made %d calls, want 1", calls)
+	}
+}
+
+func TestReadTimeoutReportsProgress(t *testing.T) {
+	lookup := newTestAccount()
+	lookup.delay = 50 * time.Millisecond
+	resolver := newTestResolver(lookup)
+	resolver.readTimeout = 75 * time.Millisecond
+	resolver.requestTimeout = time.Minute
+
+	ctx, cancel := resolver.withReadTimeout(context.Background())
+	defer cancel()
+	_, err := resolver.resolveFileContentByReference(ctx, secretReference{vault: "Shared", item: "Database", field: "config.json"})
+
+	if !errors.Is(err, errReadTimeout) {
+		t.Fatalf("resolveFileContentByReference error = %v, want %v", err, errReadTimeout)
+	}
+	if errors.Is(err, errRequestTimeout) {
+		t.Errorf("resolveFileContentByReference error = %v, want the read timeout not to be reported as request timeout", err)
+	}
+	if want := "while looking up the item 'Database'"; !strings.Contains(err.Error(), want) {
+		t.Errorf("resolveFileContentByReference error = %v, want it to contain %q", err, want)
+	}
+}
+
+// cancellingLookup cancels the context of the resolution once the vaults have been listed, like a user interrupting terraform.
+type cancellingLookup struct {
+	*fakeLookup
+	cancel context.CancelFunc
+}
+
+func (l cancellingLookup) ListVaults(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	defer l.cancel()
+	return l.fakeLookup.ListVaults(ctx)
+}
+
+func TestCancelledContextMakesNoFurtherCalls(t *testing.T) {
+	t.Run("cancelled before resolving", func(t *testing.T) {
+		lookup := newTestAccount()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newTestResolver(lookup).resolve(ctx, "op://Shared/Database/config.json", fileEncodingBase64)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("resolve error = %v, want %v", err, context.Canceled)
+		}
+		if calls := lookup.callCount(); calls != 0 {
+			t.Errorf("resolve made %d calls, want none", calls)
+		}
+	})
+
+	t.Run("cancelled while resolving", func(t *testing.T) {
+		lookup := newTestAccount()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		resolver := newTestResolver(cancellingLookup{fakeLookup: lookup, cancel: cancel})
+		resolver.maxRetries = 3
+
+		// resolving the file needs to list the vaults and items, get the item and read the file
+		_, err := resolver.resolve(ctx, "op://Shared/Database/config.json", fileEncodingBase64)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("resolve error = %v, want %v", err, context.Canceled)
+		}
+		if calls := lookup.callCount(); calls != 1 {
+			t.Errorf("resolve made %d calls, want only the vault listing before the cancellation", calls)
+		}
+	})
+}
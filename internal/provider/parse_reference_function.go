@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseReferenceFunction{}
+
+func NewParseReferenceFunction() function.Function {
+	return &parseReferenceFunction{}
+}
+
+type parseReferenceFunction struct{}
+
+type parseReferenceFunctionModel struct {
+	Vault     types.String `tfsdk:"vault"`
+	Item      types.String `tfsdk:"item"`
+	Section   types.String `tfsdk:"section"`
+	Field     types.String `tfsdk:"field"`
+	Attribute types.String `tfsdk:"attribute"`
+	IsFile    types.Bool   `tfsdk:"is_file"`
+}
+
+func (f *parseReferenceFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_reference"
+}
+
+func (f *parseReferenceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a 1Password secret reference into its parts",
+		MarkdownDescription: "Parses the given 1Password secret reference into an object with the decoded `vault`, `item`, `section` and `field` parts, " +
+			"failing if the reference is malformed. The `vault` is null if the reference uses the default vault of the provider like `op:///item/field`, " +
+			"the `section` is null if the reference does not contain a section, " +
+			"the `field` is null if the reference points to the primary value of an item in the form `op://vault/item` " +
+			"and the `attribute` is null if the reference has no `?attribute=` query.<br>" +
+			"As file references look like field references, `is_file` only tells whether the field looks like a file name with an extension. " +
+			"1Password is not contacted to parse the reference.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "reference",
+				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"vault":     types.StringType,
+				"item":      types.StringType,
+				"section":   types.StringType,
+				"field":     types.StringType,
+				"attribute": types.StringType,
+				"is_file":   types.BoolType,
+			},
+		},
+	}
+}
+
+func (f *parseReferenceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secretReference string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secretReference))
+	if resp.Error != nil {
+		return
+	}
+
+	reference, err := parseSecretReference(secretReference)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid secret reference: "+err.Error())
+		return
+	}
+
+	parsed := parseReferenceFunctionModel{
+		Vault:     types.StringNull(),
+		Item:      types.StringValue(reference.item),
+		Section:   types.StringNull(),
+		Field:     types.StringNull(),
+		Attribute: types.StringNull(),
+		IsFile:    types.BoolValue(reference.attribute == "" && looksLikeFileName(reference.field)),
+	}
+	if reference.vault != "" {
+		parsed.Vault = types.StringValue(reference.vault)
+	}
+	if reference.section != "" {
+		parsed.Section = types.StringValue(reference.section)
+	}
+	if reference.field != "" {
+		parsed.Field = types.StringValue(reference.field)
+	}
+	if reference.attribute != "" {
+		parsed.Attribute = types.StringValue(reference.attribute)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsed))
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseReferenceFunction(t *testing.T) {
+	// parts lists the expected attributes of the parsed reference, omitted string attributes are expected to be null
+	tests := []struct {
+		name      string
+		reference string
+		parts     map[string]string
+		isFile    bool
+		wantErr   bool
+	}{
+		{name: "field", reference: "op://vault/item/field", parts: map[string]string{"vault": "vault", "item": "item", "field": "field"}},
+		{name: "section", reference: "op://vault/item/section/field", parts: map[string]string{"vault": "vault", "item": "item", "section": "section", "field": "field"}},
+		{name: "primary value", reference: "op://vault/item", parts: map[string]string{"vault": "vault", "item": "item"}},
+		{name: "default vault", reference: "op:///item/field", parts: map[string]string{"item": "item", "field": "field"}},
+		{name: "file", reference: "op://vault/item/cert.pem", parts: map[string]string{"vault": "vault", "item": "item", "field": "cert.pem"}, isFile: true},
+		{name: "attribute", reference: "op://vault/item/one-time password?attribute=otp", parts: map[string]string{"vault": "vault", "item": "item", "field": "one-time password", "attribute": "otp"}},
+		{name: "attribute of field with extension", reference: "op://vault/item/config.json?attribute=type", parts: map[string]string{"vault": "vault", "item": "item", "field": "config.json", "attribute": "type"}},
+		{name: "encoded titles", reference: "op://My%20Vault/CI%20%2F%20CD/API%20Key", parts: map[string]string{"vault": "My Vault", "item": "CI / CD", "field": "API Key"}},
+		{name: "bare reference", reference: "vault/item/field", parts: map[string]string{"vault": "vault", "item": "item", "field": "field"}},
+		{name: "wrong scheme", reference: "https://vault/item/field", wantErr: true},
+		{name: "too few segments", reference: "op://vault", wantErr: true},
+		{name: "too many segments", reference: "op://vault/item/section/field/extra", wantErr: true},
+		{name: "unsupported attribute", reference: "op://vault/item/field?attribute=unknown", wantErr: true},
+		{name: "share link", reference: "https://share.1password.com/s#abc", wantErr: true},
+	}
+	attributeTypes := map[string]attr.Type{
+		"vault":     types.StringType,
+		"item":      types.StringType,
+		"section":   types.StringType,
+		"field":     types.StringType,
+		"attribute": types.StringType,
+		"is_file":   types.BoolType,
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.reference)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(attributeTypes))}
+
+			NewParseReferenceFunction().Run(context.Background(), req, resp)
+			if (resp.Error != nil) != test.wantErr {
+				t.Fatalf("parse_reference(%q) error = %v, want error %v", test.reference, resp.Error, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			result, ok := resp.Result.Value().(types.Object)
+			if !ok {
+				t.Fatalf("parse_reference(%q) returned %T, want an object", test.reference, resp.Result.Value())
+			}
+			attributes := result.Attributes()
+			for _, name := range []string{"vault", "item", "section", "field", "attribute"} {
+				want := types.StringNull()
+				if part, ok := test.parts[name]; ok {
+					want = types.StringValue(part)
+				}
+				if !attributes[name].Equal(want) {
+					t.Errorf("parse_reference(%q).%s = %v, want %v", test.reference, name, attributes[name], want)
+				}
+			}
+			if !attributes["is_file"].Equal(types.BoolValue(test.isFile)) {
+				t.Errorf("parse_reference(%q).is_file = %v, want %v", test.reference, attributes["is_file"], test.isFile)
+			}
+		})
+	}
+}
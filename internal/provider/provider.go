@@ -5,7 +5,12 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
 	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -13,10 +18,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
+// defaultMaxFileSize is the default maximum size in bytes of file attachments to read.
+const defaultMaxFileSize = 1 << 20
+
+// defaultIntegrationName identifies the provider in the 1Password audit logs unless configured otherwise.
+const defaultIntegrationName = "Onepassword secret terraform provider"
+
 // Ensure OPSecretReferenceProvider satisfies various provider interfaces.
 var _ provider.Provider = &OPSecretReferenceProvider{}
 var _ provider.ProviderWithFunctions = &OPSecretReferenceProvider{}
@@ -28,11 +44,53 @@ type OPSecretReferenceProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// resolver is set once the provider has been configured and allows
+	// provider functions to reuse the configured client.
+	resolver      *secretReferenceResolver
+	resolverMutex sync.Mutex
+
+	// connectRootCAs are the CAs trusted by Connect clients, nil meaning the system CAs.
+	connectRootCAs *x509.CertPool
+
+	// terraformVersion is the version of terraform core configuring the provider,
+	// empty if the provider has not been configured yet or terraform did not send it.
+	terraformVersion string
 }
 
 // OPSecretReferenceProviderModel describes the provider data model.
 type OPSecretReferenceProviderModel struct {
+	ServiceAccountToken     types.String                             `tfsdk:"service_account_token"`
+	ServiceAccountTokenFile types.String                             `tfsdk:"service_account_token_file"`
+	RequestTimeout          types.String                             `tfsdk:"request_timeout"`
+	ReadTimeout             types.String                             `tfsdk:"read_timeout"`
+	MaxRetries              types.Int64                              `tfsdk:"max_retries"`
+	RetryBackoff            types.String                             `tfsdk:"retry_backoff"`
+	MaxConcurrency          types.Int64                              `tfsdk:"max_concurrency"`
+	ConnectHost             types.String                             `tfsdk:"connect_host"`
+	ConnectToken            types.String                             `tfsdk:"connect_token"`
+	ConnectCACert           types.String                             `tfsdk:"connect_ca_cert"`
+	CaseInsensitiveLookup   types.Bool                               `tfsdk:"case_insensitive_lookup"`
+	Accounts                map[string]OPSecretReferenceAccountModel `tfsdk:"accounts"`
+	IntegrationName         types.String                             `tfsdk:"integration_name"`
+	ValidateToken           types.Bool                               `tfsdk:"validate_token"`
+	ProxyUrl                types.String                             `tfsdk:"proxy_url"`
+	FailFast                types.Bool                               `tfsdk:"fail_fast"`
+	FailOnEmptyValue        types.Bool                               `tfsdk:"fail_on_empty_value"`
+	CacheSecrets            types.Bool                               `tfsdk:"cache_secrets"`
+	ReadOnly                types.Bool                               `tfsdk:"read_only"`
+	UseCli                  types.Bool                               `tfsdk:"use_cli"`
+	Aliases                 map[string]string                        `tfsdk:"aliases"`
+	EnableFileFallback      types.Bool                               `tfsdk:"enable_file_fallback"`
+	DefaultVault            types.String                             `tfsdk:"default_vault"`
+	MaxFileSize             types.Int64                              `tfsdk:"max_file_size"`
+}
+
+// OPSecretReferenceAccountModel describes an additional named account of the provider.
+type OPSecretReferenceAccountModel struct {
 	ServiceAccountToken types.String `tfsdk:"service_account_token"`
+	ConnectHost         types.String `tfsdk:"connect_host"`
+	ConnectToken        types.String `tfsdk:"connect_token"`
 }
 
 func (p *OPSecretReferenceProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -48,6 +106,154 @@ func (p *OPSecretReferenceProvider) Schema(ctx context.Context, req provider.Sch
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"service_account_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the token for the Onepassword service account, with surrounding whitespace being ignored.<br>If not provided directly the OP_SERVICE_ACCOUNT_TOKEN_FILE environment variable will be used instead. Takes precedence over the OP_SERVICE_ACCOUNT_TOKEN environment variable, but not over `service_account_token`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout applied to each request to 1Password, as a duration string like `30s`.<br>If not provided no additional timeout is applied.",
+				Optional:            true,
+			},
+			"read_timeout": schema.StringAttribute{
+				MarkdownDescription: "Overall timeout of reading a secret reference, file or document, as a duration string like `2m`.<br>" +
+					"Bounds all requests of a single read together, e.g. looking up the vault and item and reading the file of a file reference, including retries. " +
+					"Applies to the `opsecret_secret_reference`, `opsecret_secret_references`, `opsecret_secret_reference_list`, `opsecret_dotenv` and `opsecret_document` data sources and the `opsecret_secret_reference` ephemeral resource. " +
+					"Complements `request_timeout`, which bounds each request on its own. If not provided no additional timeout is applied.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries of requests to 1Password failing with transient errors like rate limiting, server errors or network timeouts. Defaults to `0`.<br>Authentication and not found errors are never retried. Server errors and network failures are recognized for Connect servers only, as the SDK and the 1Password CLI do not report their cause.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_backoff": schema.StringAttribute{
+				MarkdownDescription: "Time to wait before the first retry, as a duration string like `1s`. The wait time doubles with each further retry. Defaults to `1s`.<br>" +
+					"Rate limited requests wait at least as long as requested by the `Retry-After` header of Connect servers, but are not retried if the server asks to wait for more than 5 minutes.",
+				Optional: true,
+			},
+			"connect_host": schema.StringAttribute{
+				MarkdownDescription: "URL of a 1Password Connect server to use instead of a service account, e.g. `http://localhost:8080`.<br>If not provided directly the OP_CONNECT_HOST environment variable will be used instead. Cannot be combined with a service account token.",
+				Optional:            true,
+			},
+			"connect_token": schema.StringAttribute{
+				MarkdownDescription: "Token for the 1Password Connect server.<br>If not provided directly the OP_CONNECT_TOKEN environment variable will be used instead.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"connect_ca_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate of a private CA to trust in addition to the system CAs when talking to Connect servers, either inline or as path of a PEM file.<br>" +
+					"Also applies to the Connect servers of the `accounts`.",
+				Optional: true,
+			},
+			"case_insensitive_lookup": schema.BoolAttribute{
+				MarkdownDescription: "Match vault and item titles ignoring case and leading or trailing whitespace. Defaults to `false`.<br>Regardless of this option, an error listing the candidates is returned if a title matches multiple vaults or items.",
+				Optional:            true,
+			},
+			"integration_name": schema.StringAttribute{
+				MarkdownDescription: "Name identifying the provider in the 1Password audit logs, along with the provider and terraform versions. Defaults to the `OP_INTEGRATION_NAME` environment variable, or `" + defaultIntegrationName + "` if unset. The reported version may be overridden using the `OP_INTEGRATION_VERSION` environment variable.<br>Has no effect when using a Connect server.",
+				Optional:            true,
+			},
+			"validate_token": schema.BoolAttribute{
+				MarkdownDescription: "Verify the configured tokens by listing the accessible vaults while configuring the provider, so invalid or expired tokens are reported before reading any secret. Defaults to `false`.",
+				Optional:            true,
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of secret references resolved in parallel by a batch like `opsecret_secret_references` or `resolve_all`. Defaults to `%d`.<br>", defaultMaxConcurrency) +
+					"Lower it if large batches are throttled by 1Password. A reference waiting to be retried keeps its slot until it is resolved, " +
+					"so with `max_retries` the number of requests in flight never exceeds the limit, but throttled batches take correspondingly longer. " +
+					"Independent data sources are read in parallel by terraform as limited by its `-parallelism` flag.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"fail_fast": schema.BoolAttribute{
+				MarkdownDescription: "Stop resolving the secret references of a batch like `opsecret_secret_references` at the first failure. Defaults to `false`, reporting the errors of all failed references together.<br>" +
+					"Independent data sources are not affected, as terraform always reports the errors of all failed data sources.",
+				Optional: true,
+			},
+			"fail_on_empty_value": schema.BoolAttribute{
+				MarkdownDescription: "Fail if a secret reference resolves to an empty value. Defaults to `false`, only emitting a warning.",
+				Optional:            true,
+			},
+			"cache_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Resolve each secret reference only once per terraform run, reusing the value for all data sources and functions using the exact same reference. Defaults to `false`.<br>" +
+					"Cached values are kept in memory only and discarded at the end of each run, so subsequent runs always read the current values. " +
+					"Values changed by resources of this provider during the run are resolved again.",
+				Optional: true,
+			},
+			"use_cli": schema.BoolAttribute{
+				MarkdownDescription: "Read secrets using the 1Password CLI `op` signed in on this machine if neither a service account token nor a Connect server is configured. Defaults to `false`.<br>" +
+					"Meant for running `terraform plan` locally without a service account, e.g. using the integration with the 1Password desktop app. " +
+					"The CLI must be installed on the PATH and selects the account itself, e.g. by the OP_ACCOUNT environment variable. " +
+					"Only reading is supported, and each read runs the CLI, which is considerably slower than the SDK. Never enable it in CI, where a service account token should be used instead.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Never modify 1Password, rejecting any creation, update or deletion of the resources `opsecret_item`, `opsecret_item_field`, `opsecret_file` and `opsecret_generated_password` when planning. Defaults to `false`.<br>" +
+					"Allows platform teams to hand out provider configurations which are guaranteed to only read secrets. Data sources, ephemeral resources, functions and `opsecret_local_file` are not affected.",
+				Optional: true,
+			},
+			"max_file_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum size in bytes of file attachments and documents to read, checked before downloading them. Defaults to `%d` (1 MiB), `0` disables the limit.<br>", defaultMaxFileSize) +
+					"Protects from accidentally storing large files in the terraform state. Files managed by `opsecret_file` are not limited.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"aliases": schema.MapAttribute{
+				MarkdownDescription: "Secret references keyed by logical names, which can be used instead of the references by all data sources, ephemeral resources and functions, e.g. `database_password`.<br>" +
+					"Keeps the physical layout of vaults and items in one place, so reorganizing them only requires updating the aliases. Names may contain letters, digits, `_`, `.` and `-`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(aliasNamePattern, "must only contain letters, digits, '_', '.' and '-'")),
+					mapvalidator.ValueStringsAre(secretReferenceValidator{rejectAliases: true}),
+				},
+			},
+			"default_vault": schema.StringAttribute{
+				MarkdownDescription: "The title or ID of the vault used by secret references with an empty vault segment like `op:///item-name/field-name`.<br>" +
+					"If not provided, resolving such references fails.",
+				Optional: true,
+			},
+			"enable_file_fallback": schema.BoolAttribute{
+				MarkdownDescription: "Look up secret references as file attachments step by step, if they look like file names or cannot be resolved directly. Defaults to `true`.<br>" +
+					"Disabling the fallback saves the additional requests to list and read the item when a reference cannot be resolved, and reports the error of 1Password directly. " +
+					"Only disable it if no secret references point to file attachments, as binary files and files without an extension can then no longer be resolved.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of a forward proxy to send all requests to 1Password and Connect servers through, e.g. `http://proxy.example.com:3128`.<br>" +
+					"If not provided the standard HTTPS_PROXY and HTTP_PROXY environment variables are used instead. " +
+					"Hosts listed in the NO_PROXY environment variable, e.g. a Connect server within the internal network, are never accessed through the proxy.",
+				Optional: true,
+			},
+			"accounts": schema.MapNestedAttribute{
+				MarkdownDescription: "Additional 1Password accounts keyed by an arbitrary name, selected by the `account` attribute of data sources and ephemeral resources.<br>" +
+					"Each account either uses a service account token or a 1Password Connect server. Environment variables are not considered for additional accounts.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_account_token": schema.StringAttribute{
+							MarkdownDescription: "Token for the Onepassword service account of the account.",
+							Optional:            true,
+							Sensitive:           true,
+						},
+						"connect_host": schema.StringAttribute{
+							MarkdownDescription: "URL of the 1Password Connect server of the account.",
+							Optional:            true,
+						},
+						"connect_token": schema.StringAttribute{
+							MarkdownDescription: "Token for the 1Password Connect server of the account.",
+							Optional:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -55,6 +261,10 @@ func (p *OPSecretReferenceProvider) Schema(ctx context.Context, req provider.Sch
 func (p *OPSecretReferenceProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config OPSecretReferenceProviderModel
 
+	p.resolverMutex.Lock()
+	p.terraformVersion = req.TerraformVersion
+	p.resolverMutex.Unlock()
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	if resp.Diagnostics.HasError() {
@@ -62,55 +272,476 @@ func (p *OPSecretReferenceProvider) Configure(ctx context.Context, req provider.
 	}
 
 	// Configuration values are now available.
-	token := ""
-	envToken := os.Getenv("OP_SERVICE_ACCOUNT_TOKEN")
-	if (config.ServiceAccountToken.IsUnknown() || config.ServiceAccountToken.ValueString() == "") && envToken == "" {
+	token, tokenSource, err := serviceAccountToken(config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service_account_token_file"),
+			"Unable to read Service Account Token File",
+			fmt.Sprintf("The service account token cannot be read: %s", err.Error()),
+		)
+		return
+	}
+	if token != "" {
+		tflog.Info(ctx, "Using service account token", map[string]interface{}{"source": tokenSource})
+	}
+	// a configured token which is unknown or empty, e.g. from a missing variable, silently falls back to another source otherwise
+	if !config.ServiceAccountToken.IsNull() && tokenSource != "service_account_token" && token != "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("service_account_token"),
+			"Service Account Token Not Used",
+			fmt.Sprintf("The configured service_account_token is unknown or empty, so the token of %s is used instead. "+
+				"Make sure the intended token is used, as a token left over in the environment may belong to another account.", tokenSource),
+		)
+	}
+
+	connectHost := config.ConnectHost.ValueString()
+	if connectHost == "" {
+		connectHost = os.Getenv("OP_CONNECT_HOST")
+	}
+	connectToken := config.ConnectToken.ValueString()
+	if connectToken == "" {
+		connectToken = os.Getenv("OP_CONNECT_TOKEN")
+	}
+	useConnect := connectHost != "" || connectToken != ""
+	useCli := !useConnect && token == "" && config.UseCli.ValueBool()
+
+	switch {
+	case useConnect && token != "":
+		resp.Diagnostics.AddError(
+			"Conflicting Authentication Configuration",
+			"The provider can either use a service account token or a 1Password Connect server, but both are configured. "+
+				"Remove either the service account token (service_account_token, service_account_token_file, OP_SERVICE_ACCOUNT_TOKEN_FILE or OP_SERVICE_ACCOUNT_TOKEN) "+
+				"or the Connect server configuration (connect_host / connect_token or OP_CONNECT_HOST / OP_CONNECT_TOKEN).",
+		)
+	case useConnect && connectHost == "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connect_host"),
+			"Missing Connect Host",
+			"The provider cannot create the 1Password Connect client as the Connect server host is missing. "+
+				"Either set the value statically in the configuration, or use the OP_CONNECT_HOST environment variable.",
+		)
+	case useConnect && connectToken == "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connect_token"),
+			"Missing Connect Token",
+			"The provider cannot create the 1Password Connect client as the Connect server token is missing. "+
+				"Either set the value statically in the configuration, or use the OP_CONNECT_TOKEN environment variable.",
+		)
+	case !useConnect && !useCli && token == "":
 		resp.Diagnostics.AddAttributeError(
 			path.Root("service_account_token"),
 			"Unknown or missing Service Account Token",
 			"The provider cannot create the Onepassword API client as the service account token is missing. "+
-				"Either set the value statically in the configuration, or use the OP_SERVICE_ACCOUNT_TOKEN environment variable.",
+				"Either set the value statically in the configuration, read it from a file using service_account_token_file or the OP_SERVICE_ACCOUNT_TOKEN_FILE environment variable, "+
+				"or use the OP_SERVICE_ACCOUNT_TOKEN environment variable. For local development, set use_cli to read secrets using the signed in 1Password CLI instead.",
 		)
 	}
 
-	if !config.ServiceAccountToken.IsUnknown() && config.ServiceAccountToken.ValueString() != "" {
-		token = config.ServiceAccountToken.String()
-	} else {
-		token = envToken
+	var requestTimeout time.Duration
+	if config.RequestTimeout.ValueString() != "" {
+		var err error
+		requestTimeout, err = parsePositiveDuration(config.RequestTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request timeout must be a positive duration string like \"30s\": %s", err.Error()),
+			)
+		}
 	}
-	client, err := onepassword.NewClient(
-		ctx,
-		onepassword.WithServiceAccountToken(token),
-		onepassword.WithIntegrationInfo("Onepassword secret terraform provider", "v0.0.1"),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed creating onepassword client", err.Error())
+
+	var readTimeout time.Duration
+	if config.ReadTimeout.ValueString() != "" {
+		var err error
+		readTimeout, err = parsePositiveDuration(config.ReadTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_timeout"),
+				"Invalid Read Timeout",
+				fmt.Sprintf("The read timeout must be a positive duration string like \"2m\": %s", err.Error()),
+			)
+		}
+	}
+
+	retryBackoff := time.Second
+	if config.RetryBackoff.ValueString() != "" {
+		var err error
+		retryBackoff, err = parsePositiveDuration(config.RetryBackoff.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry backoff must be a positive duration string like \"1s\": %s", err.Error()),
+			)
+		}
+	}
+
+	if config.ConnectCACert.ValueString() != "" {
+		rootCAs, err := loadConnectCACerts(config.ConnectCACert.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connect_ca_cert"),
+				"Invalid Connect CA Certificate",
+				fmt.Sprintf("The CA certificate must be PEM encoded, either inline or as path of a PEM file: %s", err.Error()),
+			)
+		}
+		p.resolverMutex.Lock()
+		p.connectRootCAs = rootCAs
+		p.resolverMutex.Unlock()
+	}
+
+	if config.ProxyUrl.ValueString() != "" {
+		proxyUrl, err := parseProxyUrl(config.ProxyUrl.ValueString())
+		if err == nil {
+			err = configureProxy(proxyUrl)
+			tflog.Debug(ctx, "Routing requests through proxy", map[string]interface{}{"proxy_url": proxyUrl.Redacted()})
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy cannot be configured: %s", err.Error()),
+			)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	integrationName := envIntegrationName()
+	if config.IntegrationName.ValueString() != "" {
+		integrationName = config.IntegrationName.ValueString()
+	}
+
+	// errors of the SDK or a Connect server must never disclose any token
+	secrets := newRedactor()
+	secrets.add(token, connectToken)
+	for _, account := range config.Accounts {
+		secrets.add(account.ServiceAccountToken.ValueString(), account.ConnectToken.ValueString())
+	}
+
+	var client *onepassword.Client
+	switch {
+	case useConnect:
+		client = p.newConnectClient(connectHost, connectToken)
+	case useCli:
+		tflog.Info(ctx, "Using 1Password CLI, as no service account token is configured")
+		var err error
+		client, err = newCliClient()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("use_cli"),
+				"1Password CLI Not Available",
+				fmt.Sprintf("The provider is configured to use the 1Password CLI as no service account token is configured, but %s. "+
+					"Install the CLI from https://developer.1password.com/docs/cli/get-started/ and sign in, or configure a service account token.", err.Error()),
+			)
+			return
+		}
+	default:
+		var err error
+		client, err = p.newOnePasswordClient(ctx, token, integrationName)
+		if err != nil {
+			summary, detail := describeClientError(secrets.wrap(err), token)
+			resp.Diagnostics.AddAttributeError(path.Root("service_account_token"), summary, detail)
+			return
+		}
+	}
+
+	resolver := &secretReferenceResolver{
+		client:         client,
+		lookup:         newClientLookup(client),
+		requestTimeout: requestTimeout,
+		readTimeout:    readTimeout,
+		maxRetries:     int(config.MaxRetries.ValueInt64()),
+		retryBackoff:   retryBackoff,
+		maxConcurrency: int(config.MaxConcurrency.ValueInt64()),
+		// terraform starts a new provider process for each run, so cached lookups never outlive a single run
+		cache:                 newLookupCache(),
+		caseInsensitiveLookup: config.CaseInsensitiveLookup.ValueBool(),
+		failFast:              config.FailFast.ValueBool(),
+		failOnEmptyValue:      config.FailOnEmptyValue.ValueBool(),
+		disableFileFallback:   !config.EnableFileFallback.IsNull() && !config.EnableFileFallback.ValueBool(),
+		defaultVault:          config.DefaultVault.ValueString(),
+		aliases:               config.Aliases,
+		maxFileSize:           defaultMaxFileSize,
+		readOnly:              config.ReadOnly.ValueBool(),
+		redactor:              secrets,
+	}
+
+	if !config.MaxFileSize.IsNull() {
+		resolver.maxFileSize = config.MaxFileSize.ValueInt64()
+	}
+	if config.CacheSecrets.ValueBool() {
+		// like the lookup cache, cached secrets never outlive the provider process of a single run
+		resolver.secrets = newSecretCache()
+	}
+
+	resolver.accounts = make(map[string]*secretReferenceResolver, len(config.Accounts))
+	for name, account := range config.Accounts {
+		accountClient, err := p.newAccountClient(ctx, account, integrationName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("accounts").AtMapKey(name),
+				"Invalid Account Configuration",
+				fmt.Sprintf("The client for the account '%s' cannot be created: %s", name, secrets.redact(err.Error())),
+			)
+			continue
+		}
+		accountResolver := *resolver
+		accountResolver.client = accountClient
+		accountResolver.lookup = newClientLookup(accountClient)
+		accountResolver.cache = newLookupCache()
+		if resolver.secrets != nil {
+			accountResolver.secrets = newSecretCache()
+		}
+		accountResolver.accounts = nil
+		resolver.accounts[name] = &accountResolver
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ValidateToken.ValueBool() {
+		if err := resolver.validateToken(ctx); err != nil {
+			if useConnect || useCli {
+				resp.Diagnostics.AddError("Token Validation Failed", err.Error())
+			} else {
+				summary, detail := describeClientError(err, token)
+				resp.Diagnostics.AddError("Token Validation Failed: "+summary, detail)
+			}
+		}
+		for name, accountResolver := range resolver.accounts {
+			if err := accountResolver.validateToken(ctx); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("accounts").AtMapKey(name),
+					"Token Validation Failed",
+					fmt.Sprintf("Account '%s': %s", name, err.Error()),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// never log any token, only which kind of authentication is used
+	tflog.Debug(ctx, "Configured 1Password client", map[string]interface{}{"connect": useConnect, "cli": useCli, "accounts": len(resolver.accounts)})
+
+	resp.DataSourceData = resolver
+	resp.ResourceData = resolver
+	resp.EphemeralResourceData = resolver
+	p.resolverMutex.Lock()
+	p.resolver = resolver
+	p.resolverMutex.Unlock()
 }
 
 func (p *OPSecretReferenceProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewItemResource,
+		NewItemFieldResource,
+		NewFileResource,
+		NewLocalFileResource,
+		NewGeneratedPasswordResource,
+	}
 }
 
 func (p *OPSecretReferenceProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return nil
+	return []func() ephemeral.EphemeralResource{
+		NewSecretReferenceEphemeralResource,
+		NewSshKeyEphemeralResource,
+	}
 }
 
 func (p *OPSecretReferenceProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSecretReferenceDataSource,
+		NewSecretReferencesDataSource,
+		NewSecretReferenceListDataSource,
+		NewDotenvDataSource,
+		NewVaultsDataSource,
+		NewVaultDataSource,
+		NewItemsDataSource,
+		NewItemDataSource,
+		NewItemFieldsDataSource,
+		NewTotpDataSource,
+		NewDocumentDataSource,
+		NewSshKeyDataSource,
+		NewFieldDataSource,
+		NewItemMetadataDataSource,
+		NewTaggedItemDataSource,
+		NewReferenceCheckDataSource,
+		NewStatusDataSource,
+		NewLoginDataSource,
+		NewCreditCardDataSource,
+		NewIdentityDataSource,
+		NewNoteDataSource,
 	}
 }
 
 func (p *OPSecretReferenceProvider) Functions(ctx context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		func() function.Function { return NewResolveFunction(p) },
+		func() function.Function { return NewResolveAllFunction(p) },
+		func() function.Function { return NewDecodeFileFunction(p) },
+		NewParseReferenceFunction,
+		NewBuildReferenceFunction,
+		func() function.Function { return NewTotpFunction(p) },
+	}
+}
+
+// functionResolver returns the resolver of the configured provider.
+// As terraform may call provider functions without configuring the provider first,
+// a client is created using the OP_CONNECT_HOST and OP_CONNECT_TOKEN or the OP_SERVICE_ACCOUNT_TOKEN_FILE or OP_SERVICE_ACCOUNT_TOKEN
+// environment variables in that case.
+func (p *OPSecretReferenceProvider) functionResolver(ctx context.Context) (*secretReferenceResolver, error) {
+	p.resolverMutex.Lock()
+	defer p.resolverMutex.Unlock()
+
+	if p.resolver != nil {
+		return p.resolver, nil
+	}
+
+	if connectHost, connectToken := os.Getenv("OP_CONNECT_HOST"), os.Getenv("OP_CONNECT_TOKEN"); connectHost != "" && connectToken != "" {
+		secrets := newRedactor()
+		secrets.add(connectToken)
+		connectClient := p.newConnectClient(connectHost, connectToken)
+		p.resolver = &secretReferenceResolver{client: connectClient, lookup: newClientLookup(connectClient), cache: newLookupCache(), maxFileSize: defaultMaxFileSize, redactor: secrets}
+		return p.resolver, nil
+	}
+
+	token := os.Getenv("OP_SERVICE_ACCOUNT_TOKEN")
+	if tokenFile := os.Getenv("OP_SERVICE_ACCOUNT_TOKEN_FILE"); tokenFile != "" {
+		var err error
+		if token, err = readTokenFile(tokenFile); err != nil {
+			return nil, fmt.Errorf("reading the service account token from OP_SERVICE_ACCOUNT_TOKEN_FILE failed: %w", err)
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("the provider is not configured and neither the OP_SERVICE_ACCOUNT_TOKEN_FILE, the OP_SERVICE_ACCOUNT_TOKEN nor the OP_CONNECT_HOST and OP_CONNECT_TOKEN environment variables are set")
+	}
+
+	secrets := newRedactor()
+	secrets.add(token)
+	client, err := p.newOnePasswordClient(ctx, token, envIntegrationName())
+	if err != nil {
+		return nil, secrets.wrap(err)
+	}
+	p.resolver = &secretReferenceResolver{client: client, lookup: newClientLookup(client), cache: newLookupCache(), maxFileSize: defaultMaxFileSize, redactor: secrets}
+
+	return p.resolver, nil
+}
+
+// serviceAccountToken returns the raw service account token along with its source, taken from the configuration,
+// the configured token file, the OP_SERVICE_ACCOUNT_TOKEN_FILE or the OP_SERVICE_ACCOUNT_TOKEN environment variable in this order.
+// The token is empty if none of them is set.
+func serviceAccountToken(config OPSecretReferenceProviderModel) (string, string, error) {
+	if !config.ServiceAccountToken.IsUnknown() && config.ServiceAccountToken.ValueString() != "" {
+		return config.ServiceAccountToken.ValueString(), "service_account_token", nil
+	}
+
+	tokenFile, tokenSource := config.ServiceAccountTokenFile.ValueString(), "service_account_token_file"
+	if tokenFile == "" {
+		tokenFile, tokenSource = os.Getenv("OP_SERVICE_ACCOUNT_TOKEN_FILE"), "OP_SERVICE_ACCOUNT_TOKEN_FILE"
+	}
+	if tokenFile != "" {
+		token, err := readTokenFile(tokenFile)
+		if err != nil {
+			return "", "", fmt.Errorf("reading the file '%s' of %s failed: %w", tokenFile, tokenSource, err)
+		}
+		return token, tokenSource, nil
+	}
+
+	return os.Getenv("OP_SERVICE_ACCOUNT_TOKEN"), "OP_SERVICE_ACCOUNT_TOKEN", nil
+}
+
+// newOnePasswordClient creates a new onepassword client authenticating with the given service account token,
+// identified by the given integration name and the provider and terraform versions.
+func (p *OPSecretReferenceProvider) newOnePasswordClient(ctx context.Context, token string, integrationName string) (*onepassword.Client, error) {
+	return onepassword.NewClient(
+		ctx,
+		onepassword.WithServiceAccountToken(token),
+		onepassword.WithIntegrationInfo(integrationName, p.integrationVersion()),
+	)
+}
+
+// integrationVersion returns the provider version along with the terraform version, if known,
+// so accesses in the 1Password activity logs can be traced back to the tools used.
+// Pipelines may label their accesses distinctly using the OP_INTEGRATION_VERSION environment variable instead.
+func (p *OPSecretReferenceProvider) integrationVersion() string {
+	if version := os.Getenv("OP_INTEGRATION_VERSION"); version != "" {
+		return version
+	}
+	if p.terraformVersion == "" {
+		return p.version
+	}
+	return fmt.Sprintf("%s (Terraform %s)", p.version, p.terraformVersion)
+}
+
+// newConnectClient creates a new onepassword client for the Connect server at the given host,
+// identified by the provider and terraform versions and trusting the configured CAs.
+func (p *OPSecretReferenceProvider) newConnectClient(host string, token string) *onepassword.Client {
+	return newConnectClient(host, token, p.userAgent(), p.connectRootCAs)
+}
+
+// userAgent returns the User-Agent header identifying the provider and terraform versions to Connect servers.
+func (p *OPSecretReferenceProvider) userAgent() string {
+	userAgent := "terraform-provider-opsecret/" + p.version
+	if p.terraformVersion != "" {
+		userAgent += " terraform/" + p.terraformVersion
+	}
+	return userAgent
+}
+
+// envIntegrationName returns the integration name of the OP_INTEGRATION_NAME environment variable,
+// falling back to the default integration name.
+func envIntegrationName() string {
+	if name := os.Getenv("OP_INTEGRATION_NAME"); name != "" {
+		return name
+	}
+	return defaultIntegrationName
+}
+
+// readTokenFile reads a token from the file at the given path,
+// trimming surrounding whitespace like the trailing newline of mounted secret files.
+func readTokenFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// parsePositiveDuration parses the given duration string, rejecting zero and negative durations
+// which would make every request time out or retry without waiting.
+func parsePositiveDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("the duration %q is not positive", value)
+	}
+	return duration, nil
+}
+
+// newAccountClient creates a new onepassword client for the given additional account,
+// using either its service account token or its Connect server.
+func (p *OPSecretReferenceProvider) newAccountClient(ctx context.Context, account OPSecretReferenceAccountModel, integrationName string) (*onepassword.Client, error) {
+	token := account.ServiceAccountToken.ValueString()
+	connectHost := account.ConnectHost.ValueString()
+	connectToken := account.ConnectToken.ValueString()
+
+	switch {
+	case token != "" && (connectHost != "" || connectToken != ""):
+		return nil, fmt.Errorf("either service_account_token or connect_host and connect_token must be set, but not both")
+	case token != "":
+		return p.newOnePasswordClient(ctx, token, integrationName)
+	case connectHost != "" && connectToken != "":
+		return p.newConnectClient(connectHost, connectToken), nil
+	default:
+		return nil, fmt.Errorf("either service_account_token or both connect_host and connect_token must be set")
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestServiceAccountTokenIsPassedVerbatim(t *testing.T) {
+	t.Setenv("OP_SERVICE_ACCOUNT_TOKEN", "")
+	t.Setenv("OP_SERVICE_ACCOUNT_TOKEN_FILE", "")
+
+	// a token with characters Go would quote or escape, to catch types.String.String() being used instead of ValueString()
+	const inlineToken = `ops_eyJzaWduSW5BZGRyZXNzIjoibXkuMXBhc3N3b3JkLmNvbSJ9"\`
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("ops_from_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		config     OPSecretReferenceProviderModel
+		env        string
+		wantToken  string
+		wantSource string
+	}{
+		{
+			name:       "inline token",
+			config:     OPSecretReferenceProviderModel{ServiceAccountToken: types.StringValue(inlineToken)},
+			wantToken:  inlineToken,
+			wantSource: "service_account_token",
+		},
+		{
+			name:       "token file",
+			config:     OPSecretReferenceProviderModel{ServiceAccountTokenFile: types.StringValue(tokenFile)},
+			wantToken:  "ops_from_file",
+			wantSource: "service_account_token_file",
+		},
+		{
+			name:       "environment",
+			env:        inlineToken,
+			wantToken:  inlineToken,
+			wantSource: "OP_SERVICE_ACCOUNT_TOKEN",
+		},
+		{
+			name:       "unknown inline token falls back to the environment",
+			config:     OPSecretReferenceProviderModel{ServiceAccountToken: types.StringUnknown()},
+			env:        "ops_from_env",
+			wantToken:  "ops_from_env",
+			wantSource: "OP_SERVICE_ACCOUNT_TOKEN",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("OP_SERVICE_ACCOUNT_TOKEN", test.env)
+
+			token, source, err := serviceAccountToken(test.config)
+			if err != nil {
+				t.Fatalf("serviceAccountToken failed: %v", err)
+			}
+			if token != test.wantToken {
+				t.Errorf("serviceAccountToken = %q, want exactly %q", token, test.wantToken)
+			}
+			if source != test.wantSource {
+				t.Errorf("serviceAccountToken source = %q, want %q", source, test.wantSource)
+			}
+		})
+	}
+}
+
+func TestServiceAccountTokenReportsUnreadableFile(t *testing.T) {
+	t.Setenv("OP_SERVICE_ACCOUNT_TOKEN_FILE", "")
+
+	config := OPSecretReferenceProviderModel{ServiceAccountTokenFile: types.StringValue(filepath.Join(t.TempDir(), "missing"))}
+	if _, _, err := serviceAccountToken(config); err == nil {
+		t.Error("serviceAccountToken succeeded, want an error for the missing token file")
+	}
+}
+
+func TestParsePositiveDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "30s", want: 30 * time.Second},
+		{value: "1m30s", want: 90 * time.Second},
+		{value: "0s", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "-5s", wantErr: true},
+		{value: "30", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := parsePositiveDuration(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parsePositiveDuration(%q) error = %v, want error %v", test.value, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("parsePositiveDuration(%q) = %v, want %v", test.value, got, test.want)
+			}
+		})
+	}
+}
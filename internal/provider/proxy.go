@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// parses and validates the given proxy URL, which must use the http, https or socks5 scheme and contain a host,
+// returning the parsed URL and nil or nil and an error object if the URL is invalid.
+func parseProxyUrl(proxyUrl string) (*url.URL, error) {
+	parsed, err := url.Parse(proxyUrl)
+	if err != nil {
+		return nil, err
+	}
+	switch parsed.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("the proxy URL '%s' must use the http, https or socks5 scheme", proxyUrl)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("the proxy URL '%s' must contain a host", proxyUrl)
+	}
+	return parsed, nil
+}
+
+// configureProxy routes all requests to 1Password and Connect servers through the given proxy,
+// except for the hosts excluded by the NO_PROXY environment variable.
+// The SDK sends its requests using the default HTTP client and offers no way to configure its transport,
+// so the proxy is applied to the default transport, which Connect clients created afterwards copy as well.
+func configureProxy(proxyUrl *url.URL) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("the default HTTP transport has the unexpected type %T", http.DefaultTransport)
+	}
+
+	config := httpproxy.Config{
+		HTTPProxy:  proxyUrl.String(),
+		HTTPSProxy: proxyUrl.String(),
+		NoProxy:    noProxyFromEnvironment(),
+	}
+	proxyFunc := config.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return nil
+}
+
+// returns the value of the NO_PROXY or no_proxy environment variable, the former taking precedence.
+func noProxyFromEnvironment() string {
+	if noProxy := os.Getenv("NO_PROXY"); noProxy != "" {
+		return noProxy
+	}
+	return os.Getenv("no_proxy")
+}
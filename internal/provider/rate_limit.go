@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// maxRetryAfter bounds the wait time requested by servers before retrying rate limited requests,
+// so a server asking to wait for a long time fails the request instead of stalling the terraform run.
+const maxRetryAfter = 5 * time.Minute
+
+// rateLimitError is returned by the Connect client if the server rejects a request due to rate limiting,
+// carrying the hints of the response headers.
+type rateLimitError struct {
+	err error
+
+	// retryAfter is the wait time requested by the server, zero if unknown.
+	retryAfter time.Duration
+
+	// remaining is the remaining request quota reported by the server, empty if unknown.
+	remaining string
+}
+
+// newRateLimitError creates a rate limit error for the given error, reading the hints from the given response headers.
+func newRateLimitError(err error, header http.Header) *rateLimitError {
+	return &rateLimitError{
+		err:        err,
+		retryAfter: parseRetryAfter(header.Get("Retry-After"), time.Now()),
+		remaining:  header.Get("X-RateLimit-Remaining"),
+	}
+}
+
+func (e *rateLimitError) Error() string {
+	var hints []string
+	if e.retryAfter > 0 {
+		hints = append(hints, fmt.Sprintf("retry after %s", e.retryAfter))
+	}
+	if e.remaining != "" {
+		hints = append(hints, fmt.Sprintf("remaining quota %s", e.remaining))
+	}
+	if len(hints) == 0 {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("%s (%s)", e.err.Error(), strings.Join(hints, ", "))
+}
+
+func (e *rateLimitError) Unwrap() error {
+	return e.err
+}
+
+// parses the given Retry-After header value, either in seconds or as HTTP date relative to the given time,
+// returning zero if the value is missing or malformed.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now).Round(time.Second)
+	}
+	return 0
+}
+
+// isRateLimitError reports whether the given error is caused by rate limiting,
+// as reported by the typed errors of the SDK and the Connect client.
+func isRateLimitError(err error) bool {
+	var sdkErr *onepassword.RateLimitExceededError
+	var connectErr *rateLimitError
+	return errors.As(err, &sdkErr) || errors.As(err, &connectErr)
+}
+
+// returns the wait time requested by the server for the given error, zero if the server gave no hint.
+func retryAfterHint(err error) time.Duration {
+	var connectErr *rateLimitError
+	if errors.As(err, &connectErr) {
+		return connectErr.retryAfter
+	}
+	return 0
+}
+
+// adds guidance on how to avoid the failure to the given rate limit error, depending on the number of retries made.
+func withRateLimitGuidance(err error, retries int) error {
+	if retries == 0 {
+		return fmt.Errorf("%w. The request was rate limited by 1Password, set max_retries to retry rate limited requests", err)
+	}
+	return fmt.Errorf("%w. The request was still rate limited by 1Password after %d retries, consider increasing max_retries or retry_backoff, or reducing the parallelism of terraform", err, retries)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// errReadOnly is returned by all calls modifying 1Password if the provider is configured to be read-only.
+var errReadOnly = errors.New("the provider is configured with read_only = true and never modifies 1Password")
+
+// checkWritable returns errReadOnly if the resolver must not modify 1Password, nil otherwise.
+func (r *secretReferenceResolver) checkWritable() error {
+	if r.readOnly {
+		return errReadOnly
+	}
+	return nil
+}
+
+// checkReadOnlyPlan reports an error if the given plan of a resource modifying 1Password would create, update or delete it
+// while the provider is read-only, so the violation is caught when planning instead of failing halfway through an apply.
+// Resources without changes are accepted, so existing resources can still be refreshed.
+func checkReadOnlyPlan(resolver *secretReferenceResolver, req resource.ModifyPlanRequest, diags *diag.Diagnostics) {
+	// the provider is not configured yet when validating the configuration
+	if resolver == nil || !resolver.readOnly {
+		return
+	}
+	if req.State.Raw.Equal(req.Plan.Raw) {
+		return
+	}
+
+	action := "update"
+	switch {
+	case req.State.Raw.IsNull():
+		action = "create"
+	case req.Plan.Raw.IsNull():
+		action = "delete"
+	}
+	diags.AddError(
+		"Provider Is Read-Only",
+		"The provider is configured with read_only = true, so this resource cannot "+action+" anything in 1Password. "+
+			"Remove the resource from the configuration and, for existing resources, from the state without destroying it, "+
+			"or use a provider configuration without read_only.",
+	)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"sync"
+)
+
+// redactedPlaceholder replaces sensitive values in error messages.
+const redactedPlaceholder = "[REDACTED]"
+
+// minRedactedLength is the minimum length of values to redact,
+// so short values like single digits do not garble unrelated parts of error messages.
+const minRedactedLength = 4
+
+// redactor removes known sensitive values like tokens and resolved secrets from error messages,
+// as errors of the SDK or a Connect server may embed them and end up in terraform diagnostics or logs.
+type redactor struct {
+	mutex  sync.RWMutex
+	values map[string]struct{}
+}
+
+func newRedactor() *redactor {
+	return &redactor{values: map[string]struct{}{}}
+}
+
+// add registers the given values as sensitive.
+func (r *redactor) add(values ...string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, value := range values {
+		if len(value) >= minRedactedLength {
+			r.values[value] = struct{}{}
+		}
+	}
+}
+
+// redact replaces all registered sensitive values within the given message.
+func (r *redactor) redact(message string) string {
+	if r == nil {
+		return message
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for value := range r.values {
+		message = strings.ReplaceAll(message, value, redactedPlaceholder)
+	}
+	return message
+}
+
+// wrap returns the given error with all registered sensitive values redacted from its message,
+// keeping the original error available for errors.Is and errors.As.
+func (r *redactor) wrap(err error) error {
+	if r == nil || err == nil {
+		return err
+	}
+	return &redactedError{err: err, redactor: r}
+}
+
+// redactedError redacts sensitive values from the message of the wrapped error.
+type redactedError struct {
+	err      error
+	redactor *redactor
+}
+
+func (e *redactedError) Error() string {
+	return e.redactor.redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactorWrap(t *testing.T) {
+	const token = "ops_eyJzaWduSW5BZGRyZXNzIjoibXkuMXBhc3N3b3JkLmNvbSJ9"
+	errInvalid := errors.New("invalid token " + token + ", the pin 123 is wrong")
+	secrets := newRedactor()
+	secrets.add(token, "123")
+
+	err := secrets.wrap(errInvalid)
+	if strings.Contains(err.Error(), token) {
+		t.Errorf("wrapped error %q contains the token", err)
+	}
+	if !strings.Contains(err.Error(), redactedPlaceholder) {
+		t.Errorf("wrapped error %q does not mark the redacted token", err)
+	}
+	if !strings.Contains(err.Error(), "123") {
+		t.Errorf("wrapped error %q redacted a value shorter than %d characters", err, minRedactedLength)
+	}
+	if !errors.Is(err, errInvalid) {
+		t.Error("wrapped error does not unwrap to the original error")
+	}
+	if secrets.wrap(nil) != nil {
+		t.Error("wrapping no error returned an error")
+	}
+}
+
+func TestResolveRedactsSecretsFromErrors(t *testing.T) {
+	const token = "ops_eyJzaWduSW5BZGRyZXNzIjoibXkuMXBhc3N3b3JkLmNvbSJ9"
+	lookup := newTestAccount()
+	resolver := newTestResolver(lookup)
+	resolver.redactor.add(token)
+
+	// resolving a secret registers its value as sensitive
+	if _, err := resolver.resolve(context.Background(), "op://Shared/Database/password", fileEncodingBase64); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	lookup.errs = []error{errors.New("field validation failed for value 'secret-password' using token " + token)}
+	_, err := resolver.resolve(context.Background(), "op://Shared/Database/username", fileEncodingBase64)
+	if err == nil {
+		t.Fatal("resolve succeeded, want the injected error")
+	}
+	for _, secret := range []string{"secret-password", token} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("resolve error %q contains the secret %q", err, secret)
+		}
+	}
+}
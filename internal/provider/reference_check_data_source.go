@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &referenceCheckDataSource{}
+	_ datasource.DataSourceWithConfigure = &referenceCheckDataSource{}
+)
+
+// Possible outcomes of checking a secret reference.
+const (
+	referenceStatusOk               = "ok"
+	referenceStatusNotFound         = "not_found"
+	referenceStatusPermissionDenied = "permission_denied"
+)
+
+func NewReferenceCheckDataSource() datasource.DataSource {
+	return &referenceCheckDataSource{}
+}
+
+type referenceCheckDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type referenceCheckDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Account types.String `tfsdk:"account"`
+	Exists  types.Bool   `tfsdk:"exists"`
+	Status  types.String `tfsdk:"status"`
+	Detail  types.String `tfsdk:"detail"`
+	ItemID  types.String `tfsdk:"item_id"`
+}
+
+func (d *referenceCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *referenceCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reference_check"
+}
+
+func (d *referenceCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that a secret reference points to an existing and accessible field or file attachment, without reading its value.<br>" +
+			"Missing or inaccessible secrets do not fail the data source, so they can be reported by checks or preconditions, e.g. in a validation stage of a pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.String{
+					secretReferenceValidator{},
+				},
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the referenced field or file attachment exists and is accessible.",
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The outcome of the check, one of `" + referenceStatusOk + "`, `" + referenceStatusNotFound + "` or `" + referenceStatusPermissionDenied + "`.<br>" +
+					"Vaults the token has not been granted access to are not visible at all and are reported as `" + referenceStatusNotFound + "`.",
+			},
+			"detail": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The reason why the reference could not be resolved, only set if it does not exist.",
+			},
+			"item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the referenced item, only set if the reference exists.",
+			},
+		},
+	}
+}
+
+func (d *referenceCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state referenceCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := d.resolver.forAccount(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	itemId, err := resolver.checkReference(ctx, state.ID.ValueString())
+	switch {
+	case err == nil:
+		state.Exists = types.BoolValue(true)
+		state.Status = types.StringValue(referenceStatusOk)
+		state.Detail = types.StringNull()
+		state.ItemID = types.StringValue(itemId)
+	case errors.Is(err, errVaultNotFound) || errors.Is(err, errItemNotFound) || errors.Is(err, errFieldNotFound):
+		state.setMissing(referenceStatusNotFound, err)
+	// permission errors are checked first, as their messages may also look like not found errors
+	case isPermissionError(err):
+		state.setMissing(referenceStatusPermissionDenied, err)
+	case isNotFoundError(err):
+		state.setMissing(referenceStatusNotFound, err)
+	default:
+		resp.Diagnostics.AddError(
+			"Unable to check secret reference",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// sets the computed attributes of the model for a reference that does not exist or is not accessible.
+func (m *referenceCheckDataSourceModel) setMissing(status string, err error) {
+	m.Exists = types.BoolValue(false)
+	m.Status = types.StringValue(status)
+	m.Detail = types.StringValue(err.Error())
+	m.ItemID = types.StringNull()
+}
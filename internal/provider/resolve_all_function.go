@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &resolveAllFunction{}
+
+func NewResolveAllFunction(provider *OPSecretReferenceProvider) function.Function {
+	return &resolveAllFunction{provider: provider}
+}
+
+type resolveAllFunction struct {
+	provider *OPSecretReferenceProvider
+}
+
+func (f *resolveAllFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resolve_all"
+}
+
+func (f *resolveAllFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Resolves a map of 1Password secret references",
+		MarkdownDescription: "Resolves all 1Password secret references of the given map concurrently into a map of their secret values with the same keys, " +
+			"e.g. to populate the environment variables of a container definition. Fails naming the keys of all references which cannot be resolved.<br>" +
+			"If the provider has not been configured yet, the OP_CONNECT_HOST and OP_CONNECT_TOKEN or the OP_SERVICE_ACCOUNT_TOKEN environment variables are used to authenticate.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "references",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The 1Password secret references keyed by arbitrary names.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *resolveAllFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secretReferences map[string]string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secretReferences))
+	if resp.Error != nil {
+		return
+	}
+
+	resolver, err := f.provider.functionResolver(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to create onepassword client: " + err.Error())
+		return
+	}
+
+	resolved, failed := resolver.resolveAll(ctx, secretReferences, fileEncodingBase64)
+	if len(failed) > 0 {
+		// report the failed references in a stable order
+		failedKeys := make([]string, 0, len(failed))
+		for key := range failed {
+			failedKeys = append(failedKeys, key)
+		}
+		sort.Strings(failedKeys)
+		messages := make([]string, 0, len(failedKeys))
+		for _, key := range failedKeys {
+			messages = append(messages, fmt.Sprintf("key '%s': %s", key, failed[key].Error()))
+		}
+		resp.Error = function.NewArgumentFuncError(0, "Unable to read secret references: "+strings.Join(messages, "; "))
+		return
+	}
+
+	values := make(map[string]string, len(resolved))
+	for key, secret := range resolved {
+		values[key] = secret.value
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, values))
+}
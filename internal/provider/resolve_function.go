@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &resolveFunction{}
+
+func NewResolveFunction(provider *OPSecretReferenceProvider) function.Function {
+	return &resolveFunction{provider: provider}
+}
+
+type resolveFunction struct {
+	provider *OPSecretReferenceProvider
+}
+
+func (f *resolveFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resolve"
+}
+
+func (f *resolveFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Resolves a 1Password secret reference",
+		MarkdownDescription: "Resolves the given 1Password secret reference into its secret value.<br>" +
+			"If the provider has not been configured yet, the OP_CONNECT_HOST and OP_CONNECT_TOKEN or the OP_SERVICE_ACCOUNT_TOKEN environment variables are used to authenticate.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "reference",
+				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *resolveFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secretReference string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secretReference))
+	if resp.Error != nil {
+		return
+	}
+
+	resolver, err := f.provider.functionResolver(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to create onepassword client: " + err.Error())
+		return
+	}
+
+	resolved, err := resolver.resolve(ctx, secretReference, fileEncodingBase64)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to read secret reference: "+err.Error())
+		return
+	}
+	// functions cannot emit warnings, so empty values are only reported if they are errors
+	if resolved.value == "" && resolver.failOnEmptyValue {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The secret reference '%s' resolved to an empty value", secretReference))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, resolved.value))
+}
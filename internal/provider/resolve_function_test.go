@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// callFunction calls the provider function with the given name and string argument through the plugin protocol,
+// the way terraform calls it for provider::opsecret::<name>(argument).
+func callFunction(t *testing.T, p *OPSecretReferenceProvider, name string, argument string) (tftypes.Value, *tfprotov6.FunctionError) {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatalf("creating the provider server failed: %v", err)
+	}
+	dynamicArgument, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, argument))
+	if err != nil {
+		t.Fatalf("encoding the argument failed: %v", err)
+	}
+
+	resp, err := server.CallFunction(context.Background(), &tfprotov6.CallFunctionRequest{
+		Name:      name,
+		Arguments: []*tfprotov6.DynamicValue{&dynamicArgument},
+	})
+	if err != nil {
+		t.Fatalf("calling the function %s failed: %v", name, err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(tftypes.String)
+	if err != nil {
+		t.Fatalf("decoding the result of the function %s failed: %v", name, err)
+	}
+	return result, nil
+}
+
+func TestResolveFunction(t *testing.T) {
+	p := &OPSecretReferenceProvider{version: "test", resolver: newTestResolver(newTestAccount())}
+
+	result, funcErr := callFunction(t, p, "resolve", "op://Shared/Database/password")
+	if funcErr != nil {
+		t.Fatalf("resolve failed for a valid reference: %s", funcErr.Text)
+	}
+	var value string
+	if err := result.As(&value); err != nil || value != "secret-password" {
+		t.Errorf("resolve returned %q (%v), want %q", value, err, "secret-password")
+	}
+
+	for _, reference := range []string{"op://Shared/Database/missing", "op://Shared/Unknown/password", "not a reference"} {
+		_, funcErr := callFunction(t, p, "resolve", reference)
+		if funcErr == nil {
+			t.Errorf("resolve(%q) succeeded, want a function error", reference)
+			continue
+		}
+		if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+			t.Errorf("resolve(%q) returned the error %q, want it to point to the reference argument", reference, funcErr.Text)
+		}
+	}
+}
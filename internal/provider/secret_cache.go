@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+)
+
+// secretCache caches resolved secrets keyed by the exact secret reference and the file encoding,
+// so references used by many data sources are resolved only once per run.
+// Concurrent resolutions of the same reference wait for the first one instead of calling 1Password again.
+type secretCache struct {
+	mutex   sync.Mutex
+	secrets map[secretCacheKey]*cachedListing[resolvedSecret]
+}
+
+// secretCacheKey identifies a cached secret, as file contents are encoded differently depending on the requested encoding.
+type secretCacheKey struct {
+	reference string
+	encoding  string
+}
+
+func newSecretCache() *secretCache {
+	return &secretCache{
+		secrets: map[secretCacheKey]*cachedListing[resolvedSecret]{},
+	}
+}
+
+// get returns the cached secret of the given reference and encoding, resolving it with the given function if not cached yet.
+// Failed resolutions are not cached.
+func (c *secretCache) get(reference string, encoding string, resolve func() (resolvedSecret, error)) (resolvedSecret, error) {
+	c.mutex.Lock()
+	key := secretCacheKey{reference: reference, encoding: encoding}
+	entry, ok := c.secrets[key]
+	if !ok {
+		entry = &cachedListing[resolvedSecret]{}
+		c.secrets[key] = entry
+	}
+	c.mutex.Unlock()
+
+	return entry.get(resolve)
+}
+
+// clear drops all cached secrets, so values changed by this provider are resolved again.
+func (c *secretCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	clear(c.secrets)
+}
+
+// invalidate drops the cached secrets of the given reference in all encodings, so it is resolved again on the next access.
+func (c *secretCache) invalidate(reference string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.secrets {
+		if key.reference == reference {
+			delete(c.secrets, key)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInvalidateSecretResolvesAgain(t *testing.T) {
+	const reference = "op://Shared/Database/password"
+	lookup := newTestAccount()
+	resolver := newTestResolver(lookup)
+	resolver.secrets = newSecretCache()
+
+	resolve := func() string {
+		t.Helper()
+		resolved, err := resolver.resolve(context.Background(), reference, fileEncodingBase64)
+		if err != nil {
+			t.Fatalf("resolve(%q) failed: %v", reference, err)
+		}
+		return resolved.value
+	}
+
+	resolve()
+	lookup.secrets[reference] = "rotated-password"
+	if got := resolve(); got != "secret-password" {
+		t.Errorf("resolve(%q) = %q, want the cached value", reference, got)
+	}
+
+	resolver.invalidateSecret(reference)
+	if got := resolve(); got != "rotated-password" {
+		t.Errorf("resolve(%q) after invalidating = %q, want the updated value", reference, got)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// secretLookup abstracts the read calls to 1Password used to resolve secret references and to look up vaults, items and files,
+// so the lookup logic of the resolver can be tested against vaults and items held in memory instead of a live account.
+type secretLookup interface {
+	// Resolve resolves the given secret reference directly.
+	Resolve(ctx context.Context, secretReference string) (string, error)
+
+	// ListVaults lists all vaults accessible by the token.
+	ListVaults(ctx context.Context) ([]onepassword.VaultOverview, error)
+
+	// ListItems lists all items of the vault with the given ID.
+	ListItems(ctx context.Context, vaultId string) ([]onepassword.ItemOverview, error)
+
+	// GetItem gets the details of the item with the given vault and item IDs.
+	GetItem(ctx context.Context, vaultId string, itemId string) (onepassword.Item, error)
+
+	// ReadFile reads the content of the given file attachment of the item with the given vault and item IDs.
+	ReadFile(ctx context.Context, vaultId string, itemId string, attributes onepassword.FileAttributes) ([]byte, error)
+}
+
+// clientLookup implements secretLookup using a onepassword client, be it the SDK, a Connect server or the CLI.
+type clientLookup struct {
+	client *onepassword.Client
+}
+
+// newClientLookup returns the lookup calling 1Password through the given client.
+func newClientLookup(client *onepassword.Client) secretLookup {
+	return &clientLookup{client: client}
+}
+
+func (l *clientLookup) Resolve(ctx context.Context, secretReference string) (string, error) {
+	return l.client.Secrets().Resolve(ctx, secretReference)
+}
+
+func (l *clientLookup) ListVaults(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	return l.client.Vaults().List(ctx)
+}
+
+func (l *clientLookup) ListItems(ctx context.Context, vaultId string) ([]onepassword.ItemOverview, error) {
+	return l.client.Items().List(ctx, vaultId)
+}
+
+func (l *clientLookup) GetItem(ctx context.Context, vaultId string, itemId string) (onepassword.Item, error) {
+	return l.client.Items().Get(ctx, vaultId, itemId)
+}
+
+func (l *clientLookup) ReadFile(ctx context.Context, vaultId string, itemId string, attributes onepassword.FileAttributes) ([]byte, error) {
+	return l.client.Items().Files().Read(ctx, vaultId, itemId, attributes)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// fakeLookup serves vaults, items and files held in memory, counting the calls made.
+type fakeLookup struct {
+	mutex sync.Mutex
+
+	vaults []onepassword.VaultOverview
+	// items holds the items by vault ID.
+	items map[string][]onepassword.Item
+	// files holds the file contents by file ID.
+	files map[string][]byte
+	// secrets holds the values resolved directly by secret reference.
+	secrets map[string]string
+	// resolveErr is returned when resolving references missing in secrets, defaulting to a not found error.
+	resolveErr error
+	// errs are returned by the next calls in order, before serving any data.
+	errs []error
+	// delay is waited for by each call, unless its context is done first.
+	delay time.Duration
+
+	calls int
+}
+
+// testId returns a valid 1Password ID starting with the given name.
+func testId(name string) string {
+	return name + strings.Repeat("0", 26-len(name))
+}
+
+// ptrTo returns a pointer to the given value, e.g. for optional fields of SDK types.
+func ptrTo[T any](value T) *T {
+	return &value
+}
+
+// newTestResolver returns a resolver using the given lookup, without caching or retries.
+func newTestResolver(lookup secretLookup) *secretReferenceResolver {
+	return &secretReferenceResolver{lookup: lookup, redactor: newRedactor()}
+}
+
+// nextCall counts the call, waits for the delay and returns the next injected error, if any.
+func (l *fakeLookup) nextCall(ctx context.Context) error {
+	l.mutex.Lock()
+	l.calls++
+	var err error
+	if len(l.errs) > 0 {
+		err = l.errs[0]
+		l.errs = l.errs[1:]
+	}
+	l.mutex.Unlock()
+
+	if l.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.delay):
+		}
+	}
+	return err
+}
+
+func (l *fakeLookup) callCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.calls
+}
+
+func (l *fakeLookup) Resolve(ctx context.Context, secretReference string) (string, error) {
+	if err := l.nextCall(ctx); err != nil {
+		return "", err
+	}
+	if value, ok := l.secrets[secretReference]; ok {
+		return value, nil
+	}
+	if l.resolveErr != nil {
+		return "", l.resolveErr
+	}
+	return "", fmt.Errorf("error resolving secret reference: no item matched the secret reference query")
+}
+
+func (l *fakeLookup) ListVaults(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	if err := l.nextCall(ctx); err != nil {
+		return nil, err
+	}
+	return l.vaults, nil
+}
+
+func (l *fakeLookup) ListItems(ctx context.Context, vaultId string) ([]onepassword.ItemOverview, error) {
+	if err := l.nextCall(ctx); err != nil {
+		return nil, err
+	}
+	overviews := []onepassword.ItemOverview{}
+	for _, item := range l.items[vaultId] {
+		overviews = append(overviews, onepassword.ItemOverview{ID: item.ID, Title: item.Title, Category: item.Category, VaultID: vaultId, State: onepassword.ItemStateActive})
+	}
+	return overviews, nil
+}
+
+func (l *fakeLookup) GetItem(ctx context.Context, vaultId string, itemId string) (onepassword.Item, error) {
+	if err := l.nextCall(ctx); err != nil {
+		return onepassword.Item{}, err
+	}
+	for _, item := range l.items[vaultId] {
+		if item.ID == itemId {
+			item.VaultID = vaultId
+			return item, nil
+		}
+	}
+	return onepassword.Item{}, errors.New("item not found")
+}
+
+func (l *fakeLookup) ReadFile(ctx context.Context, _ string, _ string, attributes onepassword.FileAttributes) ([]byte, error) {
+	if err := l.nextCall(ctx); err != nil {
+		return nil, err
+	}
+	content, ok := l.files[attributes.ID]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return content, nil
+}
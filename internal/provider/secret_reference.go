@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const secretReferencePrefix = "op://"
+
+// Field attributes selectable by the attribute query parameter of secret references, e.g. op://vault/item/field?attribute=otp.
+const (
+	fieldAttributeValue = "value"
+	fieldAttributeType  = "type"
+	fieldAttributeId    = "id"
+	fieldAttributeOtp   = "otp"
+	fieldAttributeTotp  = "totp"
+)
+
+// fieldAttributes holds all supported values of the attribute query parameter.
+var fieldAttributes = map[string]bool{
+	fieldAttributeValue: true,
+	fieldAttributeType:  true,
+	fieldAttributeId:    true,
+	fieldAttributeOtp:   true,
+	fieldAttributeTotp:  true,
+}
+
+// onePasswordIdPattern matches the 26 character IDs 1Password assigns to vaults, items, sections and fields.
+var onePasswordIdPattern = regexp.MustCompile(`^[a-z0-9]{26}$`)
+
+// aliasNamePattern matches the names of reference aliases, which never contain a slash unlike secret references.
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// reports whether the given secret reference is the name of a reference alias rather than a reference.
+func isAliasName(reference string) bool {
+	return aliasNamePattern.MatchString(strings.TrimSpace(reference))
+}
+
+// reports whether the given reference path element is a 1Password ID rather than a title.
+func isOnePasswordId(pathElement string) bool {
+	return onePasswordIdPattern.MatchString(pathElement)
+}
+
+// reports whether the given field of a secret reference looks like the name of a file attachment,
+// as file names usually have an extension while field labels usually do not.
+func looksLikeFileName(field string) bool {
+	return filepath.Ext(field) != ""
+}
+
+// shareLinkHost is the host of links to items shared with people outside of 1Password.
+const shareLinkHost = "share.1password.com"
+
+// reports whether the given reference is a link to an item shared using 1Password item sharing.
+func isShareLink(reference string) bool {
+	link, err := url.Parse(reference)
+	return err == nil && (link.Scheme == "https" || link.Scheme == "http") && strings.EqualFold(link.Hostname(), shareLinkHost)
+}
+
+// secretReference holds the path elements of a 1Password secret reference
+// in the form op://vault/item/field or op://vault/item/section/field.
+// References in the form op://vault/item have no field and point to the primary value of the item.
+// References with an empty vault like op:///item/field have no vault and use the default vault of the provider.
+// The attribute of the field to resolve may be selected by a query like ?attribute=otp.
+type secretReference struct {
+	vault     string
+	item      string
+	section   string
+	field     string
+	attribute string
+}
+
+// prepends the op:// scheme to bare references like vault/item/field, which are easily written by mistake,
+// and removes surrounding whitespace left over from copying references, which 1Password would reject.
+// References with another scheme or a mistyped scheme like op:/vault/item/field are returned without the whitespace,
+// so they are still rejected when parsing them instead of taking the scheme for the vault.
+func normalizeSecretReference(reference string) string {
+	reference = strings.TrimSpace(reference)
+	if strings.HasPrefix(reference, secretReferencePrefix) || strings.Contains(reference, "://") || strings.HasPrefix(strings.ToLower(reference), "op:") {
+		return reference
+	}
+	return secretReferencePrefix + reference
+}
+
+// parses the given secret reference into its percent-decoded path elements,
+// returning the parsed reference and nil or an empty reference and an error object if the reference is malformed.
+func parseSecretReference(reference string) (secretReference, error) {
+	if isShareLink(reference) {
+		return secretReference{}, fmt.Errorf(
+			"'%s' is a 1Password share link, which cannot be resolved as the 1Password SDK does not support share links. "+
+				"Share links are meant for people, ask the sender to store the secret in a vault the service account has access to and use its secret reference instead",
+			strings.SplitN(reference, "#", 2)[0],
+		)
+	}
+
+	path, found := strings.CutPrefix(normalizeSecretReference(reference), secretReferencePrefix)
+	if !found {
+		return secretReference{}, fmt.Errorf("secret reference '%s' must start with '%s'", reference, secretReferencePrefix)
+	}
+
+	path, query, hasQuery := strings.Cut(path, "?")
+	attribute, err := parseAttributeQuery(query)
+	if err != nil {
+		return secretReference{}, fmt.Errorf("secret reference '%s' has an invalid query: %w", reference, err)
+	}
+	if hasQuery && attribute == "" {
+		return secretReference{}, fmt.Errorf("secret reference '%s' must not have an empty query", reference)
+	}
+
+	// split the remaining path on each /
+	pathElements := strings.Split(path, "/")
+	if len(pathElements) < 2 || len(pathElements) > 4 {
+		return secretReference{}, fmt.Errorf(
+			"secret reference '%s' must have the form %svault/item, %svault/item/field or %svault/item/section/field, but has %d path segments",
+			reference, secretReferencePrefix, secretReferencePrefix, secretReferencePrefix, len(pathElements),
+		)
+	}
+	for i, pathElement := range pathElements {
+		// only the vault may be empty, which is replaced by the default vault when resolving the reference
+		if pathElement == "" && i > 0 {
+			return secretReference{}, fmt.Errorf("secret reference '%s' must not contain empty path segments", reference)
+		}
+		// titles containing spaces or slashes are percent-encoded within the reference
+		decoded, err := url.PathUnescape(pathElement)
+		if err != nil {
+			return secretReference{}, fmt.Errorf("secret reference '%s' contains an invalid percent-encoded path segment '%s': %w", reference, pathElement, err)
+		}
+		pathElements[i] = decoded
+	}
+
+	parsed := secretReference{
+		vault:     pathElements[0],
+		item:      pathElements[1],
+		attribute: attribute,
+	}
+	if len(pathElements) > 2 {
+		parsed.field = pathElements[len(pathElements)-1]
+	}
+	if len(pathElements) == 4 {
+		parsed.section = pathElements[2]
+	}
+	return parsed, nil
+}
+
+// String returns the secret reference with all path elements percent-encoded,
+// so titles containing spaces or slashes survive parsing the reference again.
+func (r secretReference) String() string {
+	pathElements := []string{r.vault, r.item}
+	if r.section != "" {
+		pathElements = append(pathElements, r.section)
+	}
+	if r.field != "" {
+		pathElements = append(pathElements, r.field)
+	}
+
+	for i, pathElement := range pathElements {
+		pathElements[i] = url.PathEscape(pathElement)
+	}
+	if r.attribute != "" {
+		return secretReferencePrefix + strings.Join(pathElements, "/") + "?attribute=" + url.QueryEscape(r.attribute)
+	}
+	return secretReferencePrefix + strings.Join(pathElements, "/")
+}
+
+// sdkString returns the secret reference to pass to 1Password, which takes the path elements literally without percent-decoding them.
+// Unlike String, the path elements are not escaped, so titles containing spaces like "My Vault" reach 1Password as they are.
+func (r secretReference) sdkString() string {
+	pathElements := []string{r.vault, r.item}
+	if r.section != "" {
+		pathElements = append(pathElements, r.section)
+	}
+	if r.field != "" {
+		pathElements = append(pathElements, r.field)
+	}
+	if r.attribute != "" {
+		return secretReferencePrefix + strings.Join(pathElements, "/") + "?attribute=" + r.attribute
+	}
+	return secretReferencePrefix + strings.Join(pathElements, "/")
+}
+
+// reports whether the vault or item title contains a slash, which 1Password cannot tell from the separators of the reference,
+// so the vault and item have to be referenced by their IDs instead.
+func (r secretReference) hasSlashInTitles() bool {
+	return strings.Contains(r.vault, "/") || strings.Contains(r.item, "/")
+}
+
+// parses the given query of a secret reference, which may only contain a single attribute parameter,
+// returning the lower case attribute and nil, or an empty string and an error object if the query is malformed.
+func parseAttributeQuery(query string) (string, error) {
+	if query == "" {
+		return "", nil
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", err
+	}
+	for key := range values {
+		if key != "attribute" {
+			return "", fmt.Errorf("unsupported query parameter '%s', only 'attribute' is supported", key)
+		}
+	}
+	if len(values["attribute"]) > 1 {
+		return "", fmt.Errorf("the attribute parameter must only be given once")
+	}
+
+	attribute := strings.ToLower(values.Get("attribute"))
+	if !fieldAttributes[attribute] {
+		supported := make([]string, 0, len(fieldAttributes))
+		for name := range fieldAttributes {
+			supported = append(supported, fmt.Sprintf("'%s'", name))
+		}
+		sort.Strings(supported)
+		return "", fmt.Errorf("unsupported attribute '%s', supported attributes are: %s", attribute, strings.Join(supported, ", "))
+	}
+	return attribute, nil
+}
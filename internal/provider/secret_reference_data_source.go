@@ -5,13 +5,18 @@ package provider
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
-	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,17 +26,69 @@ var (
 	_ datasource.DataSourceWithConfigure = &secretReferenceDataSource{}
 )
 
+// Sources of resolved secret values.
+const (
+	secretSourceField = "field"
+	secretSourceFile  = "file"
+)
+
+// expectableFieldTypes lists the field types which can be expected by expect_type.
+var expectableFieldTypes = []string{
+	string(onepassword.ItemFieldTypeText),
+	string(onepassword.ItemFieldTypeConcealed),
+	string(onepassword.ItemFieldTypeTOTP),
+	string(onepassword.ItemFieldTypeURL),
+	string(onepassword.ItemFieldTypeEmail),
+	string(onepassword.ItemFieldTypePhone),
+	string(onepassword.ItemFieldTypeDate),
+	string(onepassword.ItemFieldTypeMonthYear),
+	string(onepassword.ItemFieldTypeMenu),
+	string(onepassword.ItemFieldTypeAddress),
+	string(onepassword.ItemFieldTypeReference),
+	string(onepassword.ItemFieldTypeSSHKey),
+	string(onepassword.ItemFieldTypeCreditCardNumber),
+	string(onepassword.ItemFieldTypeCreditCardType),
+}
+
+// secretMask replaces the hidden characters of masked values, regardless of how many characters are hidden.
+const secretMask = "****"
+
+// defaultConsistencyTimeout is the default maximum time to wait for an updated item to become consistent.
+const defaultConsistencyTimeout = time.Minute
+
 func NewSecretReferenceDataSource() datasource.DataSource {
 	return &secretReferenceDataSource{}
 }
 
 type secretReferenceDataSource struct {
-	client *onepassword.Client
+	resolver *secretReferenceResolver
 }
 
 type secretReferenceDataSourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Value types.String `tfsdk:"value"`
+	ID            types.String  `tfsdk:"id"`
+	Encoding      types.String  `tfsdk:"encoding"`
+	Account       types.String  `tfsdk:"account"`
+	Trim          types.Bool    `tfsdk:"trim"`
+	LineEndings   types.String  `tfsdk:"line_endings"`
+	Value         types.String  `tfsdk:"value"`
+	ValueLength   types.Int64   `tfsdk:"value_length"`
+	MaskVisible   types.Int64   `tfsdk:"mask_visible"`
+	MaskedValue   types.String  `tfsdk:"masked_value"`
+	FileName      types.String  `tfsdk:"file_name"`
+	ContentType   types.String  `tfsdk:"content_type"`
+	Size          types.Int64   `tfsdk:"size"`
+	ContentSha256 types.String  `tfsdk:"content_sha256"`
+	Source        types.String  `tfsdk:"source"`
+	ExpectType    types.String  `tfsdk:"expect_type"`
+	ParseJSON     types.Bool    `tfsdk:"parse_json"`
+	JSON          types.Dynamic `tfsdk:"json"`
+	LookupIds     types.Bool    `tfsdk:"lookup_ids"`
+	VaultId       types.String  `tfsdk:"vault_id"`
+	ItemId        types.String  `tfsdk:"item_id"`
+
+	MinVersion         types.Int64  `tfsdk:"min_version"`
+	MinUpdatedAt       types.String `tfsdk:"min_updated_at"`
+	ConsistencyTimeout types.String `tfsdk:"consistency_timeout"`
 }
 
 func (d *secretReferenceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -41,16 +98,16 @@ func (d *secretReferenceDataSource) Configure(_ context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*onepassword.Client)
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *onepassword.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.resolver = resolver
 }
 
 func (d *secretReferenceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,12 +120,127 @@ func (d *secretReferenceDataSource) Schema(_ context.Context, _ datasource.Schem
 			"id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.String{
+					secretReferenceValidator{},
+				},
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"trim": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove leading and trailing whitespace like spaces, tabs and newlines from the content of file attachments before encoding it. Defaults to `false`.<br>Has no effect on references to fields.",
+			},
+			"line_endings": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Convert the line endings of the content of file attachments before encoding it, one of `preserve`, `lf` or `crlf`. Defaults to `preserve`.<br>`lf` converts CRLF line endings to LF, `crlf` converts LF line endings to CRLF. Applied after `trim`. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lineEndingsPreserve, lineEndingsLf, lineEndingsCrlf),
+				},
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of file attachment contents, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"min_version": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "The minimum version of the referenced item, e.g. the version after rotating the secret. " +
+					"If 1Password still returns an older version, the item is read again with exponential backoff until the `consistency_timeout` elapses.<br>" +
+					"Useful in pipelines reading a secret right after updating it, as reads may briefly return the previous value.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_updated_at": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The minimum time of the last update of the referenced item as RFC 3339 timestamp like `2024-01-02T15:04:05Z`. " +
+					"If 1Password still returns an item updated earlier, the item is read again with exponential backoff until the `consistency_timeout` elapses.",
+			},
+			"consistency_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum time to wait for the item to reach the `min_version` or `min_updated_at`, as a duration string like `30s`. Defaults to `" + defaultConsistencyTimeout.String() + "`.",
 			},
 			"value": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
 				MarkdownDescription: "The resolved secret value.",
 			},
+			"value_length": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "The number of characters of `value`, after encoding file contents. " +
+					"Not sensitive, so it can be used in checks or preconditions to catch implausibly short or empty secrets without disclosing them.",
+			},
+			"mask_visible": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "The number of characters of `value` shown at its start and at its end in `masked_value`. Defaults to `0`, masking the value completely.<br>" +
+					"Never more than an eighth of the characters are shown at each end, so at most a quarter of the value is disclosed and short values stay masked completely.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"masked_value": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The value with all but the characters configured by `mask_visible` replaced by `" + secretMask + "`, like `abcd" + secretMask + "wxyz`, or empty if the value is empty. " +
+					"Not sensitive, so it can be shown in outputs and logs to confirm which secret is in use.",
+			},
+			"parse_json": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Decode the resolved value, e.g. the content of a JSON configuration file, into `json`. Defaults to `false`.<br>" +
+					"File contents are decoded from their raw content regardless of the `encoding`. If the value is not valid JSON, a warning is emitted and `json` is null.",
+			},
+			"json": schema.DynamicAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The resolved value decoded like `jsondecode`, only set if `parse_json` is enabled and the value is valid JSON.",
+			},
+			"expect_type": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The expected type of the referenced field, e.g. `Concealed` for passwords, `Text` or `Totp` for one-time passwords. " +
+					"Fails if the field has another type or the reference points to a file, catching references to e.g. a username where a password was intended.<br>" +
+					"Requires an additional request to read the item, as the type is not reported when resolving references.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(expectableFieldTypes...),
+				},
+			},
+			"lookup_ids": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Look up the IDs of the referenced vault and item into `vault_id` and `item_id`, recording which item a reference by title resolved to. Defaults to `false`.<br>" +
+					"Requires additional requests to list the vaults and items, unless the reference already uses IDs.",
+			},
+			"vault_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the referenced vault, only set if `lookup_ids` is enabled.",
+			},
+			"item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the referenced item, only set if `lookup_ids` is enabled.",
+			},
+			"source": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether the value was resolved from a `field` or from the content of a `file` attachment or document.<br>" +
+					"Only file contents are encoded according to `encoding`, field values are always returned as they are.",
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the file attachment, only set if the reference points to a file.",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The MIME type of the file attachment, derived from the file name or content. Only set if the reference points to a file.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the file attachment in bytes as stored in 1Password, before trimming or converting line endings. Only set if the reference points to a file.",
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The SHA-256 hash of the file content in hex encoding, computed after trimming and converting line endings if enabled but before applying `encoding`. Only set if the reference points to a file.<br>" +
+					"Not sensitive, so it can be used to detect changes of the content in plans.",
+			},
 		},
 	}
 }
@@ -79,29 +251,104 @@ func (d *secretReferenceDataSource) Read(ctx context.Context, req datasource.Rea
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
-	// get the secret reference from input and try to resolve it directly
-	secretReference := state.ID.ValueString()
-	resolvedReferenceValue, err := d.client.Secrets().Resolve(ctx, secretReference)
+	resolver, err := d.resolver.forAccount(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
 
-	// references pointing to files cannot be resolved directly and need to be resolved step by step
-	if err != nil && err.Error() == "error resolving secret reference: unable to retrieve file content, currently only text files are supported" {
-		if rawValue, err2 := d.resolveFileContentByReference(ctx, secretReference); err2 != nil {
-			err = err2
-		} else {
-			state.Value = types.StringValue(strings.TrimSpace(base64.StdEncoding.EncodeToString(rawValue)))
-			err = nil
-		}
-	} else {
-		state.Value = types.StringValue(resolvedReferenceValue)
+	// bound all calls of this read together, on top of the timeout of each call
+	readCtx, cancel := resolver.withReadTimeout(ctx)
+	defer cancel()
+
+	resp.Diagnostics.Append(d.awaitConsistency(readCtx, resolver, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// values cached before the item became consistent may be outdated
+	if !state.MinVersion.IsNull() || !state.MinUpdatedAt.IsNull() {
+		resolver.invalidateSecret(state.ID.ValueString())
 	}
 
+	// get the secret reference from input and try to resolve it
+	resolved, err := resolver.resolve(readCtx, state.ID.ValueString(), state.Encoding.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to read secret reference",
+			readErrorSummary(err),
 			err.Error(),
 		)
 		return
 	}
+	if state.Trim.ValueBool() {
+		resolved = resolved.trimmed(state.Encoding.ValueString())
+	}
+	resolved = resolved.withLineEndings(state.LineEndings.ValueString(), state.Encoding.ValueString())
+	resolver.checkEmptyValue(&resp.Diagnostics, path.Root("id"), state.ID.ValueString(), resolved)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Value = types.StringValue(resolved.value)
+	state.ValueLength = types.Int64Value(int64(utf8.RuneCountInString(resolved.value)))
+	state.MaskedValue = types.StringValue(maskSecret(resolved.value, int(state.MaskVisible.ValueInt64())))
+
+	if !state.ExpectType.IsNull() {
+		resp.Diagnostics.Append(d.checkFieldType(readCtx, resolver, state, resolved)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// file details are only available if the reference points to a file
+	state.Source = types.StringValue(secretSourceField)
+	state.FileName = types.StringNull()
+	state.ContentType = types.StringNull()
+	state.Size = types.Int64Null()
+	state.ContentSha256 = types.StringNull()
+	if resolved.file != nil {
+		state.Source = types.StringValue(secretSourceFile)
+		state.FileName = types.StringValue(resolved.file.attributes.Name)
+		state.ContentType = types.StringValue(resolved.file.contentType())
+		state.Size = types.Int64Value(int64(resolved.file.attributes.Size))
+		state.ContentSha256 = types.StringValue(contentHash(resolved.file.content))
+	}
+
+	state.VaultId = types.StringNull()
+	state.ItemId = types.StringNull()
+	if state.LookupIds.ValueBool() {
+		vaultId, itemId, err := resolver.lookupReferenceIds(readCtx, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lookup_ids"),
+				"Unable to look up vault and item IDs",
+				err.Error(),
+			)
+			return
+		}
+		state.VaultId = types.StringValue(vaultId)
+		state.ItemId = types.StringValue(itemId)
+	}
+
+	state.JSON = types.DynamicNull()
+	if state.ParseJSON.ValueBool() {
+		content := []byte(resolved.value)
+		if resolved.file != nil {
+			content = resolved.file.content
+		}
+		decoded, err := decodeJSONValue(ctx, content)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("parse_json"),
+				"Value Is Not Valid JSON",
+				fmt.Sprintf("The value of the secret reference '%s' cannot be decoded as JSON, so json is null and only value is set: %s", state.ID.ValueString(), err.Error()),
+			)
+		} else {
+			state.JSON = types.DynamicValue(decoded)
+		}
+	}
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -111,81 +358,92 @@ func (d *secretReferenceDataSource) Read(ctx context.Context, req datasource.Rea
 	}
 }
 
-// resolves the given secret reference by resolving each reference part step by step,
-// returning the file content bytes and nil or nil and an error object if something goes wrong.
-func (d *secretReferenceDataSource) resolveFileContentByReference(ctx context.Context, secretReference string) ([]byte, error) {
-	// skip the op:// prefix and split the remaining path on each /
-	pathElements := strings.Split(secretReference[5:], "/")
-	vaultName := pathElements[0]
-	itemName := pathElements[1]
-	fileName := pathElements[2]
-
-	// get the vault ID by its name
-	vaultId, err := d.getVaultId(ctx, vaultName)
-	if err != nil {
-		return nil, err
+// masks the given secret, showing at most the given number of characters at its start and end,
+// but never more than an eighth of its characters at each end, so the secret cannot be reconstructed from it.
+func maskSecret(secret string, visible int) string {
+	if secret == "" {
+		return ""
 	}
-
-	// get the item ID by its name
-	itemId, err := d.getItemId(ctx, vaultId, itemName)
-	if err != nil {
-		return nil, err
+	characters := []rune(secret)
+	visible = min(visible, len(characters)/8)
+	if visible <= 0 {
+		return secretMask
 	}
+	return string(characters[:visible]) + secretMask + string(characters[len(characters)-visible:])
+}
 
-	// get the file contents by its name
-	fileContents, err := d.getFileByName(ctx, vaultId, itemId, fileName)
-	if err != nil {
-		return nil, err
+// verifies that the referenced field has the expected type, which requires reading the item.
+func (d *secretReferenceDataSource) checkFieldType(ctx context.Context, resolver *secretReferenceResolver, state secretReferenceDataSourceModel, resolved resolvedSecret) diag.Diagnostics {
+	var diags diag.Diagnostics
+	expectedType := state.ExpectType.ValueString()
+	if resolved.file != nil {
+		diags.AddAttributeError(
+			path.Root("expect_type"),
+			"Unexpected Field Type",
+			fmt.Sprintf("The secret reference '%s' points to the file '%s' instead of a field of type %s.", state.ID.ValueString(), resolved.file.attributes.Name, expectedType),
+		)
+		return diags
 	}
 
-	return fileContents, nil
-}
-
-// searches all available vaults, matching by given vault name
-// returns the vault ID and nil on match, empty string and an error object otherwise.
-func (d *secretReferenceDataSource) getVaultId(ctx context.Context, vaultName string) (string, error) {
-	vaults, err := d.client.Vaults().List(ctx)
+	fieldType, err := resolver.referencedFieldType(ctx, state.ID.ValueString())
 	if err != nil {
-		return "", err
+		diags.AddAttributeError(
+			path.Root("expect_type"),
+			"Unable to read field type",
+			err.Error(),
+		)
+		return diags
 	}
-	for _, vault := range vaults {
-		if vault.Title == vaultName {
-			return vault.ID, nil
-		}
+	if string(fieldType) != expectedType {
+		diags.AddAttributeError(
+			path.Root("expect_type"),
+			"Unexpected Field Type",
+			fmt.Sprintf("The secret reference '%s' points to a field of type %s, but %s is expected.", state.ID.ValueString(), fieldType, expectedType),
+		)
 	}
-	return "", fmt.Errorf("vault '%s' not found", vaultName)
+	return diags
 }
 
-// searches all available items in the given vault, matching by given item name
-// returns the item ID and nil on match, empty string and an error object otherwise.
-func (d *secretReferenceDataSource) getItemId(ctx context.Context, vaultId string, fileName string) (string, error) {
-	items, err := d.client.Items().List(ctx, vaultId)
-	if err != nil {
-		return "", err
+// waits for the referenced item to reach the configured minimum version or update time, if any.
+func (d *secretReferenceDataSource) awaitConsistency(ctx context.Context, resolver *secretReferenceResolver, state secretReferenceDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if state.MinVersion.IsNull() && state.MinUpdatedAt.IsNull() {
+		return diags
 	}
-	for _, item := range items {
-		if item.Title == fileName {
-			return item.ID, nil
+
+	var minUpdatedAt time.Time
+	if !state.MinUpdatedAt.IsNull() {
+		var err error
+		minUpdatedAt, err = time.Parse(time.RFC3339, state.MinUpdatedAt.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("min_updated_at"),
+				"Invalid Timestamp",
+				fmt.Sprintf("The minimum update time must be a RFC 3339 timestamp like \"2024-01-02T15:04:05Z\": %s", err.Error()),
+			)
+			return diags
 		}
 	}
-	return "", fmt.Errorf("item '%s' not found", fileName)
-}
 
-// searches all available file attachments in the given item, matching by given file name
-// returns the file content bytes and nil on match, nil and an error object otherwise.
-func (d *secretReferenceDataSource) getFileByName(ctx context.Context, vaultId string, itemId string, fileName string) ([]byte, error) {
-	itemDetails, err := d.client.Items().Get(ctx, vaultId, itemId)
-	if err != nil {
-		return nil, err
-	}
-	for _, fileAttachment := range itemDetails.Files {
-		if fileAttachment.Attributes.Name == fileName {
-			fileBytes, err := d.client.Items().Files().Read(ctx, vaultId, itemId, fileAttachment.Attributes)
-			if err != nil {
-				return nil, err
-			}
-			return fileBytes, nil
+	timeout := defaultConsistencyTimeout
+	if !state.ConsistencyTimeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(state.ConsistencyTimeout.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("consistency_timeout"),
+				"Invalid Consistency Timeout",
+				fmt.Sprintf("The consistency timeout must be a valid duration string like \"30s\": %s", err.Error()),
+			)
+			return diags
 		}
 	}
-	return nil, fmt.Errorf("file '%s' not found", fileName)
+
+	if err := resolver.awaitItemConsistency(ctx, state.ID.ValueString(), state.MinVersion.ValueInt64Pointer(), minUpdatedAt, timeout); err != nil {
+		diags.AddError(
+			"Item Not Yet Consistent",
+			err.Error(),
+		)
+	}
+	return diags
 }
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &secretReferenceEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &secretReferenceEphemeralResource{}
+)
+
+func NewSecretReferenceEphemeralResource() ephemeral.EphemeralResource {
+	return &secretReferenceEphemeralResource{}
+}
+
+type secretReferenceEphemeralResource struct {
+	resolver *secretReferenceResolver
+}
+
+type secretReferenceEphemeralResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Encoding    types.String `tfsdk:"encoding"`
+	Account     types.String `tfsdk:"account"`
+	Trim        types.Bool   `tfsdk:"trim"`
+	LineEndings types.String `tfsdk:"line_endings"`
+	Value       types.String `tfsdk:"value"`
+}
+
+func (e *secretReferenceEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.resolver = resolver
+}
+
+func (e *secretReferenceEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_reference"
+}
+
+func (e *secretReferenceEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a 1Password secret reference on every run without persisting the value in the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The 1Password secret reference.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.String{
+					secretReferenceValidator{},
+				},
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"trim": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove leading and trailing whitespace like spaces, tabs and newlines from the content of file attachments before encoding it. Defaults to `false`.<br>Has no effect on references to fields.",
+			},
+			"line_endings": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Convert the line endings of the content of file attachments before encoding it, one of `preserve`, `lf` or `crlf`. Defaults to `preserve`.<br>`lf` converts CRLF line endings to LF, `crlf` converts LF line endings to CRLF. Applied after `trim`. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lineEndingsPreserve, lineEndingsLf, lineEndingsCrlf),
+				},
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of file attachment contents, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The resolved secret value.",
+			},
+		},
+	}
+}
+
+func (e *secretReferenceEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var result secretReferenceEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := e.resolver.forAccount(result.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	// bound all calls resolving the reference together, on top of the timeout of each call
+	readCtx, cancel := resolver.withReadTimeout(ctx)
+	defer cancel()
+
+	// get the secret reference from input and try to resolve it
+	resolved, err := resolver.resolve(readCtx, result.ID.ValueString(), result.Encoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			readErrorSummary(err),
+			err.Error(),
+		)
+		return
+	}
+	if result.Trim.ValueBool() {
+		resolved = resolved.trimmed(result.Encoding.ValueString())
+	}
+	resolved = resolved.withLineEndings(result.LineEndings.ValueString(), result.Encoding.ValueString())
+	resolver.checkEmptyValue(&resp.Diagnostics, path.Root("id"), result.ID.ValueString(), resolved)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	result.Value = types.StringValue(resolved.value)
+
+	// Set result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
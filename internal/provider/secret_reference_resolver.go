@@ -0,0 +1,962 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Errors returned if a vault, item or file attachment could not be found by its name.
+var (
+	errVaultNotFound = errors.New("vault not found")
+	errItemNotFound  = errors.New("item not found")
+	errFileNotFound  = errors.New("file not found")
+	errFieldNotFound = errors.New("field not found")
+)
+
+// errAmbiguousMatch is returned if a vault or item name matches more than one vault or item.
+var errAmbiguousMatch = errors.New("name matches multiple entries")
+
+// defaultMaxConcurrency limits the number of secret references resolved in parallel by batch resolutions.
+const defaultMaxConcurrency = 4
+
+// Supported encodings of resolved file contents.
+const (
+	fileEncodingBase64    = "base64"
+	fileEncodingBase64Url = "base64url"
+	fileEncodingRaw       = "raw"
+	fileEncodingAuto      = "auto"
+)
+
+// Supported normalizations of the line endings of resolved file contents.
+const (
+	lineEndingsPreserve = "preserve"
+	lineEndingsLf       = "lf"
+	lineEndingsCrlf     = "crlf"
+)
+
+// Interpretations of vault and item names, see lookupBy.
+const (
+	lookupByAuto = "auto"
+	lookupByName = "name"
+	lookupById   = "id"
+)
+
+// secretReferenceResolver bundles the logic to resolve 1Password secret references,
+// shared by all data sources and ephemeral resources of this provider.
+//
+// Terraform reads data sources in parallel, so the resolver and its client are used concurrently.
+// The SDK client is safe for concurrent use, but serializes all calls as they are processed by a single WASM core,
+// while the Connect client sends concurrent requests in parallel over a pool of reused connections.
+// The caches and the redactor are guarded by mutexes, all other fields are never modified after configuring the provider.
+type secretReferenceResolver struct {
+	client *onepassword.Client
+
+	// lookup performs the read calls of the client, replaced by vaults and items held in memory in tests.
+	lookup secretLookup
+
+	// requestTimeout bounds each call to 1Password, zero meaning no additional timeout.
+	requestTimeout time.Duration
+
+	// readTimeout bounds all calls to 1Password of a single read together, zero meaning no additional timeout.
+	readTimeout time.Duration
+
+	// maxRetries is the number of retries of calls to 1Password failing with transient errors,
+	// waiting retryBackoff before the first retry and doubling the wait time on each further retry.
+	maxRetries   int
+	retryBackoff time.Duration
+
+	// maxConcurrency limits the number of secret references resolved in parallel by batch resolutions,
+	// falling back to defaultMaxConcurrency if not set.
+	maxConcurrency int
+
+	// failFast stops batch resolutions at the first failure instead of collecting the errors of all references.
+	failFast bool
+
+	// failOnEmptyValue reports empty resolved values as errors instead of warnings.
+	failOnEmptyValue bool
+
+	// maxFileSize is the maximum size in bytes of file attachments and documents to read, zero meaning no limit.
+	maxFileSize int64
+
+	// aliases maps logical names to the secret references they stand for.
+	aliases map[string]string
+
+	// defaultVault is the vault of secret references with an empty vault segment, empty meaning no default.
+	defaultVault string
+
+	// disableFileFallback resolves references only through the SDK, without looking them up as file attachments step by step.
+	// The zero value keeps the fallback enabled, also for resolvers created before the provider is configured.
+	disableFileFallback bool
+
+	// cache holds the vault and item listings for lookups by name, nil meaning no caching.
+	cache *lookupCache
+
+	// secrets holds the resolved secrets by reference, nil meaning every reference is resolved anew.
+	secrets *secretCache
+
+	// lookupBy controls whether vault and item names are interpreted as titles or IDs, one of the lookupBy constants.
+	// By default, names looking like IDs are used as IDs without a lookup, while other names are looked up by title.
+	lookupBy string
+
+	// caseInsensitiveLookup enables matching vault and item titles ignoring case and surrounding whitespace.
+	caseInsensitiveLookup bool
+
+	// accounts holds the resolvers of the additional named accounts configured in the provider.
+	accounts map[string]*secretReferenceResolver
+
+	// readOnly rejects all calls modifying 1Password, see checkWritable.
+	readOnly bool
+
+	// redactor removes tokens and resolved secrets from the errors of all calls to 1Password, nil meaning no redaction.
+	redactor *redactor
+}
+
+// resolvedSecret holds the resolved value of a secret reference
+// and the file attachment details if the reference points to a file.
+type resolvedSecret struct {
+	value string
+	file  *fileAttachment
+}
+
+// fileAttachment holds the attributes and the content of a file attachment.
+type fileAttachment struct {
+	attributes onepassword.FileAttributes
+	content    []byte
+}
+
+// contentType returns the MIME type of the file derived from its name,
+// falling back to detecting it from the file content.
+func (f *fileAttachment) contentType() string {
+	if contentType := mime.TypeByExtension(filepath.Ext(f.attributes.Name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(f.content)
+}
+
+// resolves the given secret reference directly, falling back to resolving file references step by step,
+// returning the resolved secret and nil or an empty secret and an error object if something goes wrong.
+// If secret caching is enabled, each reference is resolved only once.
+func (r *secretReferenceResolver) resolve(ctx context.Context, secretReference string, encoding string) (resolvedSecret, error) {
+	resolveUncached := func() (resolvedSecret, error) {
+		secret, err := r.resolveUncached(ctx, secretReference, encoding)
+		if err != nil {
+			err = withReadProgress(ctx, err, fmt.Sprintf("resolving the secret reference '%s'", strings.TrimSpace(secretReference)))
+			return secret, r.explainNotFound(ctx, secretReference, err)
+		}
+		return secret, nil
+	}
+	if r.secrets == nil {
+		return resolveUncached()
+	}
+	return r.secrets.get(secretReference, encoding, func() (resolvedSecret, error) {
+		tflog.Debug(ctx, "Resolving secret reference not cached yet", map[string]interface{}{"reference": secretReference})
+		return resolveUncached()
+	})
+}
+
+// adds the full secret reference and a hint on the likely cause to the given error of resolving the reference,
+// if it was caused by a missing vault, item, field or file. Other errors are returned unchanged.
+// The SDK only reports missing parts by its error messages, so the missing part is determined by looking up the reference step by step.
+func (r *secretReferenceResolver) explainNotFound(ctx context.Context, secretReference string, err error) error {
+	if !isReferenceNotFound(err) {
+		if ctx.Err() != nil || isPermissionError(err) || !isNotFoundError(err) {
+			return err
+		}
+		if _, checkErr := r.checkReference(ctx, secretReference); isReferenceNotFound(checkErr) {
+			err = checkErr
+		}
+	}
+	if _, normalizedReference, parseErr := r.parseReference(secretReference); parseErr == nil {
+		secretReference = normalizedReference
+	}
+
+	var hint string
+	switch {
+	case errors.Is(err, errVaultNotFound):
+		hint = "Check the vault name for typos and whether the token has been granted access to the vault."
+	case errors.Is(err, errItemNotFound):
+		hint = "The vault is accessible, so check the item name for typos and whether the item has been renamed, archived or deleted."
+	case errors.Is(err, errFieldNotFound) || errors.Is(err, errFileNotFound):
+		hint = "The item is accessible, so check the field label or file name for typos, they are case sensitive."
+	default:
+		hint = "Check the reference for typos and whether the token has been granted access to the vault."
+	}
+	return fmt.Errorf("secret reference '%s' not found: %w\n\n%s", secretReference, err, hint)
+}
+
+// reports whether the given error is caused by a missing vault, item, field or file found while looking up a reference step by step.
+func isReferenceNotFound(err error) bool {
+	return errors.Is(err, errVaultNotFound) || errors.Is(err, errItemNotFound) || errors.Is(err, errFieldNotFound) || errors.Is(err, errFileNotFound)
+}
+
+// resolves the given secret reference like resolve, bypassing the secret cache.
+func (r *secretReferenceResolver) resolveUncached(ctx context.Context, secretReference string, encoding string) (resolvedSecret, error) {
+	if trimmed := strings.TrimSpace(secretReference); trimmed != secretReference {
+		tflog.Debug(ctx, "Removed surrounding whitespace from secret reference", map[string]interface{}{"reference": trimmed})
+	}
+
+	// reject malformed references before calling 1Password
+	reference, secretReference, err := r.parseReference(secretReference)
+	if err != nil {
+		return resolvedSecret{}, err
+	}
+
+	if reference.field == "" {
+		tflog.Debug(ctx, "Resolving primary value of item", map[string]interface{}{"reference": secretReference})
+		return r.resolvePrimaryValue(ctx, reference, encoding)
+	}
+
+	// the SDK resolves text files directly but fails for binary files, so references looking like file attachments
+	// are resolved step by step first, making text and binary files encoded the same way
+	lookedUpFile := false
+	if reference.attribute == "" && !r.disableFileFallback && looksLikeFileName(reference.field) {
+		tflog.Debug(ctx, "Resolving secret reference as file attachment", map[string]interface{}{"reference": secretReference})
+		secret, err := r.resolveFile(ctx, reference, encoding)
+		if !errors.Is(err, errVaultNotFound) && !errors.Is(err, errItemNotFound) && !errors.Is(err, errFileNotFound) {
+			return secret, err
+		}
+		// the field label merely contains a dot, or the vault or item lookup failed which the SDK reports more precisely
+		tflog.Debug(ctx, "Secret reference does not point to a file attachment", map[string]interface{}{"reference": secretReference, "error": err.Error()})
+		lookedUpFile = true
+	}
+
+	tflog.Debug(ctx, "Resolving secret reference", map[string]interface{}{"reference": secretReference})
+	var resolvedReferenceValue string
+	var resolveErr error
+	if reference.hasSlashInTitles() {
+		// 1Password cannot tell slashes within titles from separators, so the vault and item are referenced by their IDs
+		resolvedReferenceValue, resolveErr = r.resolveSecretByIds(ctx, reference)
+	} else {
+		resolvedReferenceValue, resolveErr = r.resolveSecret(ctx, secretReference)
+	}
+	if resolveErr == nil {
+		tflog.Debug(ctx, "Resolved secret reference", map[string]interface{}{"reference": secretReference})
+		return resolvedSecret{value: resolvedReferenceValue}, nil
+	}
+	tflog.Debug(ctx, "Resolving secret reference directly failed", map[string]interface{}{"reference": secretReference, "error": resolveErr.Error()})
+
+	// with relaxed title matching, the reference is retried using the IDs of the matching vault and item
+	if r.caseInsensitiveLookup && !reference.hasSlashInTitles() {
+		value, err := r.resolveSecretByIds(ctx, reference)
+		if err == nil {
+			return resolvedSecret{value: value}, nil
+		}
+		if errors.Is(err, errAmbiguousMatch) {
+			return resolvedSecret{}, err
+		}
+	}
+
+	// file attachments have no attributes, so the reference cannot point to a file
+	if reference.attribute != "" || lookedUpFile || r.disableFileFallback {
+		return resolvedSecret{}, resolveErr
+	}
+
+	// references pointing to files without an extension cannot always be resolved directly and need to be resolved step by step,
+	// without relying on the SDK error message.
+	tflog.Debug(ctx, "Resolving secret reference as file attachment", map[string]interface{}{"reference": secretReference})
+	secret, err := r.resolveFile(ctx, reference, encoding)
+	if errors.Is(err, errVaultNotFound) || errors.Is(err, errItemNotFound) || errors.Is(err, errFileNotFound) {
+		tflog.Debug(ctx, "Secret reference does not point to a file attachment", map[string]interface{}{"reference": secretReference, "error": err.Error()})
+		// the reference does not point to a file, so the original error is the relevant one
+		return resolvedSecret{}, resolveErr
+	}
+	return secret, err
+}
+
+// parses the given secret reference or the reference of the given alias, using the default vault if the vault segment of the reference is empty.
+// Returns the parsed reference and the reference to pass to 1Password, which has the op:// scheme and the vault filled in
+// and its path elements percent-decoded, and nil, or an empty reference, an empty string and an error object if the reference is malformed or has no vault.
+func (r *secretReferenceResolver) parseReference(rawReference string) (secretReference, string, error) {
+	if isAliasName(rawReference) {
+		aliasedReference, ok := r.aliases[strings.TrimSpace(rawReference)]
+		if !ok {
+			return secretReference{}, "", fmt.Errorf("'%s' is neither a secret reference nor an alias configured in the provider aliases", strings.TrimSpace(rawReference))
+		}
+		rawReference = aliasedReference
+	}
+
+	reference, err := parseSecretReference(rawReference)
+	if err != nil {
+		return reference, "", err
+	}
+	if reference.vault == "" {
+		if r.defaultVault == "" {
+			return secretReference{}, "", fmt.Errorf("secret reference '%s' has no vault, add the vault to the reference or configure a default_vault in the provider", rawReference)
+		}
+		reference.vault = r.defaultVault
+	}
+	return reference, reference.sdkString(), nil
+}
+
+// resolves the given secret reference as file attachment, encoding its content with the given encoding,
+// returning the resolved secret and nil or an empty secret and an error object if something goes wrong.
+func (r *secretReferenceResolver) resolveFile(ctx context.Context, reference secretReference, encoding string) (resolvedSecret, error) {
+	file, err := r.resolveFileContentByReference(ctx, reference)
+	if err != nil {
+		return resolvedSecret{}, err
+	}
+	return resolvedSecret{value: encodeFileContent(file.content, encoding), file: &file}, nil
+}
+
+// resolves the given secret reference after replacing the vault and item names by the IDs of the matching vault and item,
+// returning the resolved value and nil or an empty string and an error object if something goes wrong.
+func (r *secretReferenceResolver) resolveSecretByIds(ctx context.Context, reference secretReference) (string, error) {
+	vaultId, err := r.getVaultId(ctx, reference.vault)
+	if err != nil {
+		return "", err
+	}
+
+	itemId, err := r.getItemId(ctx, vaultId, reference.item)
+	if err != nil {
+		return "", err
+	}
+
+	reference.vault = vaultId
+	reference.item = itemId
+	return r.resolveSecret(ctx, reference.sdkString())
+}
+
+// primaryFieldIds holds the IDs of the fields holding the primary value of items of the respective category.
+var primaryFieldIds = map[onepassword.ItemCategory]string{
+	onepassword.ItemCategoryLogin:          "password",
+	onepassword.ItemCategoryPassword:       "password",
+	onepassword.ItemCategoryDatabase:       "password",
+	onepassword.ItemCategoryAPICredentials: "credential",
+	onepassword.ItemCategorySSHKey:         "private_key",
+}
+
+// resolves the given secret reference without a field to the primary value of the referenced item,
+// which is the content of the document of document items, the field given by primaryFieldIds for the respective categories
+// and the only concealed field of items of any other category,
+// returning the resolved secret and nil or an empty secret and an error object if the item has no unambiguous primary value.
+func (r *secretReferenceResolver) resolvePrimaryValue(ctx context.Context, reference secretReference, encoding string) (resolvedSecret, error) {
+	item, err := r.getItem(ctx, reference.vault, reference.item)
+	if err != nil {
+		return resolvedSecret{}, err
+	}
+
+	if item.Category == onepassword.ItemCategoryDocument && item.Document != nil {
+		if reference.attribute != "" {
+			return resolvedSecret{}, fmt.Errorf("the document of item '%s' has no attribute '%s'", item.Title, reference.attribute)
+		}
+		if err := r.checkFileSize(*item.Document); err != nil {
+			return resolvedSecret{}, err
+		}
+		content, err := r.readFile(ctx, item.VaultID, item.ID, *item.Document)
+		if err != nil {
+			return resolvedSecret{}, err
+		}
+		file := fileAttachment{attributes: *item.Document, content: content}
+		return resolvedSecret{value: encodeFileContent(content, encoding), file: &file}, nil
+	}
+
+	field, err := primaryField(item)
+	if err != nil {
+		return resolvedSecret{}, fmt.Errorf("%w, add the field to the secret reference '%s'", err, reference)
+	}
+	value, err := fieldAttribute(field, reference.attribute)
+	if err != nil {
+		return resolvedSecret{}, err
+	}
+	tflog.Debug(ctx, "Resolved primary value of item", map[string]interface{}{"reference": reference.String(), "field_id": field.ID})
+	return resolvedSecret{value: value}, nil
+}
+
+// returns the given attribute of the given field and nil, where no attribute selects the value of the field,
+// or an empty string and an error object if the field has no such attribute.
+func fieldAttribute(field onepassword.ItemField, attribute string) (string, error) {
+	switch attribute {
+	case "", fieldAttributeValue:
+		return field.Value, nil
+	case fieldAttributeType:
+		return string(field.FieldType), nil
+	case fieldAttributeId:
+		return field.ID, nil
+	case fieldAttributeOtp, fieldAttributeTotp:
+		return totpCode(field)
+	}
+	return "", fmt.Errorf("field '%s' has no attribute '%s'", field.Title, attribute)
+}
+
+// returns the field holding the primary value of the given item and nil,
+// or an empty field and an error object if the item has no unambiguous primary value.
+func primaryField(item onepassword.Item) (onepassword.ItemField, error) {
+	if fieldId, ok := primaryFieldIds[item.Category]; ok {
+		for _, field := range item.Fields {
+			if field.ID == fieldId {
+				return field, nil
+			}
+		}
+		return onepassword.ItemField{}, fmt.Errorf("item '%s' of category %s has no '%s' field", item.Title, item.Category, fieldId)
+	}
+
+	var concealed []onepassword.ItemField
+	for _, field := range item.Fields {
+		if field.FieldType == onepassword.ItemFieldTypeConcealed {
+			concealed = append(concealed, field)
+		}
+	}
+	switch len(concealed) {
+	case 0:
+		return onepassword.ItemField{}, fmt.Errorf("item '%s' of category %s has no primary value as it has no concealed field", item.Title, item.Category)
+	case 1:
+		return concealed[0], nil
+	}
+	titles := make([]string, 0, len(concealed))
+	for _, field := range concealed {
+		titles = append(titles, fmt.Sprintf("'%s'", field.Title))
+	}
+	return onepassword.ItemField{}, fmt.Errorf(
+		"%w: item '%s' of category %s has no unambiguous primary value as it has the concealed fields %s",
+		errAmbiguousMatch, item.Title, item.Category, strings.Join(titles, ", "),
+	)
+}
+
+// checks that the given secret reference points to an existing field or file attachment without reading its value,
+// returning the ID of the referenced item and nil if it exists, or an empty string and an error object otherwise.
+// Missing vaults, items, fields and files are reported by errVaultNotFound, errItemNotFound, errFieldNotFound and errFileNotFound.
+func (r *secretReferenceResolver) checkReference(ctx context.Context, secretReference string) (string, error) {
+	reference, _, err := r.parseReference(secretReference)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := r.getItem(ctx, reference.vault, reference.item)
+	if err != nil {
+		return "", err
+	}
+
+	if reference.field == "" {
+		if item.Category == onepassword.ItemCategoryDocument && item.Document != nil {
+			return item.ID, nil
+		}
+		if _, err := primaryField(item); err != nil {
+			return "", fmt.Errorf("%w: %s", errFieldNotFound, err.Error())
+		}
+		return item.ID, nil
+	}
+
+	for _, field := range item.Fields {
+		if field.Title != reference.field && field.ID != reference.field {
+			continue
+		}
+		if reference.section == "" || (field.SectionID != nil && sectionMatches(item, *field.SectionID, reference.section)) {
+			return item.ID, nil
+		}
+	}
+	for _, itemFile := range item.Files {
+		if (itemFile.Attributes.Name == reference.field || itemFile.Attributes.ID == reference.field) && (reference.section == "" || sectionMatches(item, itemFile.SectionID, reference.section)) {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: '%s' is neither a field nor a file attachment of item '%s' in the vault with ID '%s'", errFieldNotFound, reference.field, item.Title, item.VaultID)
+}
+
+// looks up the IDs of the vault and item the given secret reference points to, as the SDK resolves references without reporting them,
+// returning the vault ID, the item ID and nil, or empty strings and an error object if the vault or item cannot be found.
+func (r *secretReferenceResolver) lookupReferenceIds(ctx context.Context, secretReference string) (string, string, error) {
+	reference, _, err := r.parseReference(secretReference)
+	if err != nil {
+		return "", "", err
+	}
+
+	vaultId, err := r.getVaultId(ctx, reference.vault)
+	if err != nil {
+		return "", "", withReadProgress(ctx, err, fmt.Sprintf("looking up the vault '%s'", reference.vault))
+	}
+	itemId, err := r.getItemId(ctx, vaultId, reference.item)
+	if err != nil {
+		return "", "", withReadProgress(ctx, err, fmt.Sprintf("looking up the item '%s' in the vault with ID '%s'", reference.item, vaultId))
+	}
+	return vaultId, itemId, nil
+}
+
+// looks up the type of the field the given secret reference points to, which the SDK does not report when resolving references,
+// returning the field type and nil, or an empty type and an error object if the reference does not point to a field.
+func (r *secretReferenceResolver) referencedFieldType(ctx context.Context, secretReference string) (onepassword.ItemFieldType, error) {
+	reference, _, err := r.parseReference(secretReference)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := r.getItem(ctx, reference.vault, reference.item)
+	if err != nil {
+		return "", err
+	}
+
+	var field onepassword.ItemField
+	if reference.field == "" {
+		field, err = primaryField(item)
+	} else {
+		field, err = getField(item, reference.section, reference.field)
+	}
+	if err != nil {
+		return "", err
+	}
+	return field.FieldType, nil
+}
+
+// maxConsistencyBackoff caps the wait time between reads of an item which is not yet consistent.
+const maxConsistencyBackoff = 10 * time.Second
+
+// waits until the item of the given secret reference has at least the given version, if any, and was updated
+// at or after the given time, if not zero, as reads shortly after updating an item may still return its previous version.
+// Reads the item again with exponential backoff until it is consistent or the timeout elapses,
+// returning nil once the item is consistent and an error object otherwise.
+func (r *secretReferenceResolver) awaitItemConsistency(ctx context.Context, secretReference string, minVersion *int64, minUpdatedAt time.Time, timeout time.Duration) error {
+	reference, _, err := r.parseReference(secretReference)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	wait := r.retryBackoff
+	if wait <= 0 {
+		wait = time.Second
+	}
+	for {
+		item, err := r.getItem(ctx, reference.vault, reference.item)
+		if err != nil {
+			return err
+		}
+		versionReached := minVersion == nil || int64(item.Version) >= *minVersion
+		updateReached := minUpdatedAt.IsZero() || !item.UpdatedAt.Before(minUpdatedAt)
+		if versionReached && updateReached {
+			return nil
+		}
+
+		if time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf(
+				"item '%s' still has version %d updated at %s after waiting %s for it to become consistent, expected %s",
+				item.Title, item.Version, item.UpdatedAt.Format(time.RFC3339), timeout, consistencyExpectation(minVersion, minUpdatedAt),
+			)
+		}
+		tflog.Debug(ctx, "Waiting for updated item to become consistent", map[string]interface{}{"item": item.Title, "version": item.Version, "wait": wait.String()})
+		select {
+		case <-ctx.Done():
+			return withReadProgress(ctx, ctx.Err(), fmt.Sprintf("waiting for the item '%s' to become consistent", item.Title))
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxConsistencyBackoff)
+	}
+}
+
+// describes the expected item version and update time for error messages.
+func consistencyExpectation(minVersion *int64, minUpdatedAt time.Time) string {
+	var expectations []string
+	if minVersion != nil {
+		expectations = append(expectations, fmt.Sprintf("at least version %d", *minVersion))
+	}
+	if !minUpdatedAt.IsZero() {
+		expectations = append(expectations, "an update at or after "+minUpdatedAt.Format(time.RFC3339))
+	}
+	return strings.Join(expectations, " and ")
+}
+
+// reports an empty resolved value of the given secret reference at the given attribute,
+// as a warning or as an error if configured, since empty secrets almost always indicate a misconfiguration.
+func (r *secretReferenceResolver) checkEmptyValue(diags *diag.Diagnostics, attributePath path.Path, secretReference string, secret resolvedSecret) {
+	if secret.value != "" {
+		return
+	}
+	detail := fmt.Sprintf("The secret reference '%s' resolved to an empty value. Make sure the referenced field is filled in 1Password.", secretReference)
+	if r.failOnEmptyValue {
+		diags.AddAttributeError(attributePath, "Empty Secret Value", detail)
+		return
+	}
+	diags.AddAttributeWarning(attributePath, "Empty Secret Value", detail+" Set fail_on_empty_value in the provider to fail instead.")
+}
+
+// forAccount returns the resolver of the additional account with the given name,
+// or the resolver itself if no account name is given.
+func (r *secretReferenceResolver) forAccount(account string) (*secretReferenceResolver, error) {
+	if account == "" {
+		return r, nil
+	}
+	if accountResolver, ok := r.accounts[account]; ok {
+		return accountResolver, nil
+	}
+
+	names := make([]string, 0, len(r.accounts))
+	for name := range r.accounts {
+		names = append(names, fmt.Sprintf("'%s'", name))
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the account '%s' is not configured in the provider, which has no additional accounts", account)
+	}
+	return nil, fmt.Errorf("the account '%s' is not configured in the provider, configured accounts are: %s", account, strings.Join(names, ", "))
+}
+
+// trimmed returns the secret with leading and trailing whitespace removed from its file content,
+// encoding the trimmed content with the given encoding. Resolved fields are returned unchanged.
+func (s resolvedSecret) trimmed(encoding string) resolvedSecret {
+	if s.file == nil {
+		return s
+	}
+	file := *s.file
+	file.content = bytes.TrimSpace(file.content)
+	return resolvedSecret{value: encodeFileContent(file.content, encoding), file: &file}
+}
+
+// withLineEndings returns the secret with the line endings of its file content converted as given by lineEndings,
+// encoding the converted content with the given encoding. Resolved fields are returned unchanged.
+func (s resolvedSecret) withLineEndings(lineEndings string, encoding string) resolvedSecret {
+	if s.file == nil || lineEndings == "" || lineEndings == lineEndingsPreserve {
+		return s
+	}
+	file := *s.file
+	file.content = convertLineEndings(file.content, lineEndings)
+	return resolvedSecret{value: encodeFileContent(file.content, encoding), file: &file}
+}
+
+// convertLineEndings converts all line endings of the given content to LF or CRLF, as given by lineEndings.
+// Existing CRLF line endings are not doubled when converting to CRLF. Any other value returns the content unchanged.
+func convertLineEndings(content []byte, lineEndings string) []byte {
+	switch lineEndings {
+	case lineEndingsLf:
+		return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	case lineEndingsCrlf:
+		lf := bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		return bytes.ReplaceAll(lf, []byte("\n"), []byte("\r\n"))
+	default:
+		return content
+	}
+}
+
+// withLookupCache returns a copy of the resolver caching vault and item listings,
+// reusing the existing cache if the resolver already has one.
+func (r *secretReferenceResolver) withLookupCache() *secretReferenceResolver {
+	if r.cache != nil {
+		return r
+	}
+	cached := *r
+	cached.cache = newLookupCache()
+	return &cached
+}
+
+// withLookupBy returns a copy of the resolver interpreting vault and item names as configured by the given lookup mode,
+// or the resolver itself for the automatic mode.
+func (r *secretReferenceResolver) withLookupBy(lookupBy string) *secretReferenceResolver {
+	if lookupBy == "" || lookupBy == lookupByAuto {
+		return r
+	}
+	configured := *r
+	configured.lookupBy = lookupBy
+	// the same reference may point to a different item when interpreted differently
+	configured.secrets = nil
+	return &configured
+}
+
+// resolves all given secret references concurrently, limited by the configured maximum concurrency,
+// returning the resolved secrets and the errors of failed resolutions, both keyed like the given references.
+// Vault and item listings are shared between all resolutions of the batch.
+// If fail fast is enabled, the remaining resolutions are cancelled after the first failure, which is the only error returned.
+func (r *secretReferenceResolver) resolveAll(ctx context.Context, secretReferences map[string]string, encoding string) (map[string]resolvedSecret, map[string]error) {
+	resolved := make(map[string]resolvedSecret, len(secretReferences))
+	failed := map[string]error{}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxConcurrency := r.maxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	batchResolver := r.withLookupCache()
+
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrency)
+	for key, secretReference := range secretReferences {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			secret, err := batchResolver.resolve(ctx, secretReference, encoding)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case err != nil && r.failFast:
+				// errors caused by cancelling the remaining resolutions are not reported
+				if len(failed) == 0 {
+					failed[key] = err
+					cancel()
+				}
+			case err != nil:
+				failed[key] = err
+			default:
+				resolved[key] = secret
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	return resolved, failed
+}
+
+// resolves the given secret reference by resolving each reference part step by step,
+// returning the file attachment and nil or an empty file attachment and an error object if something goes wrong.
+func (r *secretReferenceResolver) resolveFileContentByReference(ctx context.Context, reference secretReference) (fileAttachment, error) {
+	// get the vault ID by its name
+	vaultId, err := r.getVaultId(ctx, reference.vault)
+	if err != nil {
+		return fileAttachment{}, withReadProgress(ctx, err, fmt.Sprintf("looking up the vault '%s'", reference.vault))
+	}
+
+	// get the item ID by its name
+	itemId, err := r.getItemId(ctx, vaultId, reference.item)
+	if err != nil {
+		return fileAttachment{}, withReadProgress(ctx, err, fmt.Sprintf("looking up the item '%s' in the vault with ID '%s'", reference.item, vaultId))
+	}
+
+	// get the file contents by its name
+	file, err := r.getFileByName(ctx, vaultId, itemId, reference.section, reference.field)
+	if err != nil {
+		return fileAttachment{}, withReadProgress(ctx, err, fmt.Sprintf("reading the file '%s' of the item with ID '%s' in the vault with ID '%s'", reference.field, itemId, vaultId))
+	}
+
+	return file, nil
+}
+
+// searches all available vaults, matching by given vault name or ID, unless the given name already is a vault ID
+// returns the vault ID and nil on match, empty string and an error object otherwise.
+func (r *secretReferenceResolver) getVaultId(ctx context.Context, vaultName string) (string, error) {
+	// IDs are used as they are, avoiding to list all vaults
+	if r.lookupBy == lookupById || (r.lookupBy != lookupByName && isOnePasswordId(vaultName)) {
+		tflog.Trace(ctx, "Using vault ID without lookup", map[string]interface{}{"vault": vaultName})
+		return vaultName, nil
+	}
+	tflog.Trace(ctx, "Looking up vault", map[string]interface{}{"vault": vaultName})
+	vaults, err := r.listVaults(ctx)
+	if err != nil {
+		return "", err
+	}
+	var matchIds, candidates []string
+	for _, vault := range vaults {
+		if vault.ID == vaultName && r.lookupBy != lookupByName {
+			return vault.ID, nil
+		}
+		if r.titleMatches(vault.Title, vaultName) {
+			matchIds = append(matchIds, vault.ID)
+			candidates = append(candidates, fmt.Sprintf("'%s' (%s)", vault.Title, vault.ID))
+		}
+	}
+	switch {
+	case len(matchIds) == 0:
+		// vaults the token has not been granted access to are not listed at all
+		return "", fmt.Errorf(
+			"%w: '%s'. The service account or Connect token may not have been granted access to it, accessible vaults are: %s",
+			errVaultNotFound, vaultName, vaultTitles(vaults),
+		)
+	case len(matchIds) > 1:
+		return "", fmt.Errorf("%w: vault '%s' matches the vaults %s, use the vault ID instead", errAmbiguousMatch, vaultName, strings.Join(candidates, ", "))
+	}
+	tflog.Trace(ctx, "Found vault", map[string]interface{}{"vault": vaultName, "vault_id": matchIds[0]})
+	return matchIds[0], nil
+}
+
+// returns the quoted titles of the given vaults for error messages.
+func vaultTitles(vaults []onepassword.VaultOverview) string {
+	if len(vaults) == 0 {
+		return "none"
+	}
+	titles := make([]string, 0, len(vaults))
+	for _, vault := range vaults {
+		titles = append(titles, fmt.Sprintf("'%s'", vault.Title))
+	}
+	return strings.Join(titles, ", ")
+}
+
+// searches all available items in the given vault, matching by given item name or ID, unless the given name already is an item ID
+// returns the item ID and nil on match, empty string and an error object otherwise.
+func (r *secretReferenceResolver) getItemId(ctx context.Context, vaultId string, itemName string) (string, error) {
+	// IDs are used as they are, avoiding to list all items of the vault
+	if r.lookupBy == lookupById || (r.lookupBy != lookupByName && isOnePasswordId(itemName)) {
+		tflog.Trace(ctx, "Using item ID without lookup", map[string]interface{}{"vault_id": vaultId, "item": itemName})
+		return itemName, nil
+	}
+	tflog.Trace(ctx, "Looking up item", map[string]interface{}{"vault_id": vaultId, "item": itemName})
+	items, err := r.listItems(ctx, vaultId)
+	if err != nil {
+		return "", err
+	}
+	var matchIds, candidates []string
+	for _, item := range items {
+		if item.ID == itemName && r.lookupBy != lookupByName {
+			return item.ID, nil
+		}
+		if r.titleMatches(item.Title, itemName) {
+			matchIds = append(matchIds, item.ID)
+			candidates = append(candidates, fmt.Sprintf("'%s' (%s)", item.Title, item.ID))
+		}
+	}
+	switch {
+	case len(matchIds) == 0:
+		// the number of searched items shows that the listing was complete, e.g. for vaults with thousands of items
+		return "", fmt.Errorf("%w: '%s' in the vault with ID '%s', searched %d items", errItemNotFound, itemName, vaultId, len(items))
+	case len(matchIds) > 1:
+		return "", fmt.Errorf("%w: item '%s' matches the items %s, use the item ID instead", errAmbiguousMatch, itemName, strings.Join(candidates, ", "))
+	}
+	tflog.Trace(ctx, "Found item", map[string]interface{}{"vault_id": vaultId, "item": itemName, "item_id": matchIds[0]})
+	return matchIds[0], nil
+}
+
+// reports whether the given vault or item title matches the given name,
+// ignoring case and surrounding whitespace if case insensitive lookups are enabled.
+func (r *secretReferenceResolver) titleMatches(title string, name string) bool {
+	if r.caseInsensitiveLookup {
+		return strings.EqualFold(strings.TrimSpace(title), strings.TrimSpace(name))
+	}
+	return title == name
+}
+
+// looks up the item by the given vault and item names or IDs
+// returns the item details and nil on match, an empty item and an error object otherwise.
+func (r *secretReferenceResolver) getItem(ctx context.Context, vaultName string, itemName string) (onepassword.Item, error) {
+	vaultId, err := r.getVaultId(ctx, vaultName)
+	if err != nil {
+		return onepassword.Item{}, withReadProgress(ctx, err, fmt.Sprintf("looking up the vault '%s'", vaultName))
+	}
+
+	itemId, err := r.getItemId(ctx, vaultId, itemName)
+	if err != nil {
+		return onepassword.Item{}, withReadProgress(ctx, err, fmt.Sprintf("looking up the item '%s' in the vault with ID '%s'", itemName, vaultId))
+	}
+
+	item, err := r.getItemById(ctx, vaultId, itemId)
+	return item, withReadProgress(ctx, err, fmt.Sprintf("getting the item with ID '%s' in the vault with ID '%s'", itemId, vaultId))
+}
+
+// looks up the item by the given item name or ID in the given vaults in order, skipping vaults which do not exist or lack the item
+// returns the item details of the first match and nil, an empty item and an error object if no vault contains the item.
+func (r *secretReferenceResolver) getItemFromVaults(ctx context.Context, vaultNames []string, itemName string) (onepassword.Item, error) {
+	if len(vaultNames) == 1 {
+		return r.getItem(ctx, vaultNames[0], itemName)
+	}
+
+	var skipped []string
+	for _, vaultName := range vaultNames {
+		item, err := r.getItem(ctx, vaultName, itemName)
+		if errors.Is(err, errVaultNotFound) || errors.Is(err, errItemNotFound) {
+			tflog.Debug(ctx, "Item not found in vault, trying next vault", map[string]interface{}{"vault": vaultName, "item": itemName, "error": err.Error()})
+			skipped = append(skipped, err.Error())
+			continue
+		}
+		return item, err
+	}
+	return onepassword.Item{}, fmt.Errorf("%w: '%s' in none of the vaults '%s': %s", errItemNotFound, itemName, strings.Join(vaultNames, "', '"), strings.Join(skipped, "; "))
+}
+
+// searches all available file attachments in the given item, matching by given file name or ID
+// and by the title or ID of the section containing the file, if a section is given
+// returns the file attachment and nil on a unique match, an empty file attachment and an error object otherwise.
+func (r *secretReferenceResolver) getFileByName(ctx context.Context, vaultId string, itemId string, sectionName string, fileName string) (fileAttachment, error) {
+	tflog.Trace(ctx, "Looking up file attachment", map[string]interface{}{"vault_id": vaultId, "item_id": itemId, "section": sectionName, "file": fileName})
+	itemDetails, err := r.getItemById(ctx, vaultId, itemId)
+	if err != nil {
+		return fileAttachment{}, err
+	}
+	var matches []onepassword.ItemFile
+	for _, itemFile := range itemDetails.Files {
+		if sectionName != "" && !sectionMatches(itemDetails, itemFile.SectionID, sectionName) {
+			continue
+		}
+		// IDs are unique, so they disambiguate files with the same name
+		if itemFile.Attributes.ID == fileName {
+			matches = []onepassword.ItemFile{itemFile}
+			break
+		}
+		if itemFile.Attributes.Name == fileName {
+			matches = append(matches, itemFile)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return fileAttachment{}, fmt.Errorf("%w: '%s' in item '%s' of the vault with ID '%s'", errFileNotFound, fileName, itemDetails.Title, vaultId)
+	case len(matches) > 1:
+		candidates := make([]string, 0, len(matches))
+		for _, match := range matches {
+			candidates = append(candidates, fmt.Sprintf("'%s' (%d bytes)", match.Attributes.ID, match.Attributes.Size))
+		}
+		return fileAttachment{}, fmt.Errorf(
+			"%w: file name '%s' matches the files %s, use the file ID instead of the name in the secret reference",
+			errAmbiguousMatch, fileName, strings.Join(candidates, ", "),
+		)
+	}
+
+	itemFile := matches[0]
+	tflog.Trace(ctx, "Reading file attachment", map[string]interface{}{"vault_id": vaultId, "item_id": itemId, "file_id": itemFile.Attributes.ID, "size": itemFile.Attributes.Size})
+	if err := r.checkFileSize(itemFile.Attributes); err != nil {
+		return fileAttachment{}, err
+	}
+	fileBytes, err := r.readFile(ctx, vaultId, itemId, itemFile.Attributes)
+	if err != nil {
+		return fileAttachment{}, err
+	}
+	return fileAttachment{attributes: itemFile.Attributes, content: fileBytes}, nil
+}
+
+// verifies the declared size of the given file against the configured maximum before reading it,
+// so large files are neither downloaded nor stored in the terraform state.
+func (r *secretReferenceResolver) checkFileSize(attributes onepassword.FileAttributes) error {
+	if r.maxFileSize <= 0 || int64(attributes.Size) <= r.maxFileSize {
+		return nil
+	}
+	return fmt.Errorf(
+		"file '%s' has %d bytes, exceeding the max_file_size of %d bytes. Increase max_file_size in the provider or set it to 0 to read files of any size",
+		attributes.Name, attributes.Size, r.maxFileSize,
+	)
+}
+
+// reports whether the section with the given ID of the given item matches the given section title or ID.
+func sectionMatches(item onepassword.Item, sectionId string, sectionName string) bool {
+	if sectionId == sectionName {
+		return true
+	}
+	for _, section := range item.Sections {
+		if section.ID == sectionId && section.Title == sectionName {
+			return true
+		}
+	}
+	return false
+}
+
+// encodes the given file content using the given encoding,
+// where auto uses the raw content for valid UTF-8 text and base64 otherwise.
+func encodeFileContent(content []byte, encoding string) string {
+	switch encoding {
+	case fileEncodingRaw:
+		return string(content)
+	case fileEncodingBase64Url:
+		return base64.RawURLEncoding.EncodeToString(content)
+	case fileEncodingAuto:
+		if utf8.Valid(content) {
+			return string(content)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(content)
+}
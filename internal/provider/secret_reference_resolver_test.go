@@ -0,0 +1,458 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+)
+
+// newTestAccount returns a lookup serving a vault with a database item carrying files,
+// along with vaults and items sharing their titles.
+func newTestAccount() *fakeLookup {
+	shared := testId("shared")
+	return &fakeLookup{
+		vaults: []onepassword.VaultOverview{
+			{ID: shared, Title: "Shared"},
+			{ID: testId("proda"), Title: "Prod"},
+			{ID: testId("prodb"), Title: "Prod"},
+		},
+		items: map[string][]onepassword.Item{
+			shared: {
+				{
+					ID:       testId("database"),
+					Title:    "Database",
+					Category: onepassword.ItemCategoryDatabase,
+					Sections: []onepassword.ItemSection{{ID: testId("tls"), Title: "TLS"}},
+					Fields: []onepassword.ItemField{
+						{ID: "password", Title: "password", FieldType: onepassword.ItemFieldTypeConcealed, Value: "secret-password"},
+					},
+					Files: []onepassword.ItemFile{
+						{Attributes: onepassword.FileAttributes{ID: testId("config"), Name: "config.json", Size: 14}},
+						{Attributes: onepassword.FileAttributes{ID: testId("cert"), Name: "cert", Size: 4}, SectionID: testId("tls")},
+						{Attributes: onepassword.FileAttributes{ID: testId("copya"), Name: "copy.txt", Size: 1}},
+						{Attributes: onepassword.FileAttributes{ID: testId("copyb"), Name: "copy.txt", Size: 2}},
+					},
+				},
+				{ID: testId("duplicatea"), Title: "Duplicate"},
+				{ID: testId("duplicateb"), Title: "Duplicate"},
+			},
+		},
+		files: map[string][]byte{
+			testId("config"): []byte(`{"debug":true}`),
+			testId("cert"):   []byte("cert"),
+			testId("copya"):  []byte("a"),
+			testId("copyb"):  []byte("bb"),
+		},
+		secrets: map[string]string{
+			"op://Shared/Database/password": "secret-password",
+		},
+	}
+}
+
+func TestGetVaultId(t *testing.T) {
+	tests := []struct {
+		name            string
+		vault           string
+		caseInsensitive bool
+		want            string
+		wantErr         error
+	}{
+		{name: "title", vault: "Shared", want: testId("shared")},
+		{name: "id", vault: testId("shared"), want: testId("shared")},
+		{name: "unknown id is used as is", vault: testId("unknown"), want: testId("unknown")},
+		{name: "unknown title", vault: "Unknown", wantErr: errVaultNotFound},
+		{name: "duplicate title", vault: "Prod", wantErr: errAmbiguousMatch},
+		{name: "title differing in case", vault: "shared ", wantErr: errVaultNotFound},
+		{name: "case insensitive title", vault: "shared ", caseInsensitive: true, want: testId("shared")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := newTestResolver(newTestAccount())
+			resolver.caseInsensitiveLookup = test.caseInsensitive
+
+			got, err := resolver.getVaultId(context.Background(), test.vault)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("getVaultId(%q) error = %v, want %v", test.vault, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("getVaultId(%q) = %q, want %q", test.vault, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetVaultIdReportsDuplicateIds(t *testing.T) {
+	_, err := newTestResolver(newTestAccount()).getVaultId(context.Background(), "Prod")
+	for _, id := range []string{testId("proda"), testId("prodb")} {
+		if err == nil || !strings.Contains(err.Error(), id) {
+			t.Errorf("getVaultId error = %v, want it to list the conflicting ID %s", err, id)
+		}
+	}
+}
+
+func TestGetItemId(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    string
+		want    string
+		wantErr error
+	}{
+		{name: "title", item: "Database", want: testId("database")},
+		{name: "id", item: testId("database"), want: testId("database")},
+		{name: "unknown title", item: "Unknown", wantErr: errItemNotFound},
+		{name: "duplicate title", item: "Duplicate", wantErr: errAmbiguousMatch},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newTestResolver(newTestAccount()).getItemId(context.Background(), testId("shared"), test.item)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("getItemId(%q) error = %v, want %v", test.item, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("getItemId(%q) = %q, want %q", test.item, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetFileByName(t *testing.T) {
+	tests := []struct {
+		name        string
+		section     string
+		file        string
+		maxFileSize int64
+		want        string
+		wantErr     error
+	}{
+		{name: "name", file: "config.json", want: `{"debug":true}`},
+		{name: "id", file: testId("config"), want: `{"debug":true}`},
+		{name: "name in section", section: "TLS", file: "cert", want: "cert"},
+		{name: "name in other section", section: "Other", file: "cert", wantErr: errFileNotFound},
+		{name: "unknown name", file: "missing.txt", wantErr: errFileNotFound},
+		{name: "duplicate name", file: "copy.txt", wantErr: errAmbiguousMatch},
+		{name: "duplicate name by id", file: testId("copyb"), want: "bb"},
+		{name: "exceeding max file size", file: "config.json", maxFileSize: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := newTestResolver(newTestAccount())
+			resolver.maxFileSize = test.maxFileSize
+
+			got, err := resolver.getFileByName(context.Background(), testId("shared"), testId("database"), test.section, test.file)
+			if test.maxFileSize > 0 {
+				if err == nil {
+					t.Fatalf("getFileByName(%q) succeeded, want the max file size to be exceeded", test.file)
+				}
+				return
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("getFileByName(%q) error = %v, want %v", test.file, err, test.wantErr)
+			}
+			if string(got.content) != test.want {
+				t.Errorf("getFileByName(%q) = %q, want %q", test.file, got.content, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveUncachedFileFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+		wantFile  bool
+		wantErr   bool
+	}{
+		{name: "field", reference: "op://Shared/Database/password", want: "secret-password"},
+		{name: "file with extension", reference: "op://Shared/Database/config.json", want: "eyJkZWJ1ZyI6dHJ1ZX0=", wantFile: true},
+		{name: "file without extension", reference: "op://Shared/Database/TLS/cert", want: "Y2VydA==", wantFile: true},
+		{name: "missing field", reference: "op://Shared/Database/username", wantErr: true},
+		{name: "missing file", reference: "op://Shared/Database/missing.txt", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newTestResolver(newTestAccount()).resolveUncached(context.Background(), test.reference, fileEncodingBase64)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("resolveUncached(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got.value != test.want {
+				t.Errorf("resolveUncached(%q) = %q, want %q", test.reference, got.value, test.want)
+			}
+			if (got.file != nil) != test.wantFile {
+				t.Errorf("resolveUncached(%q) resolved a file = %v, want %v", test.reference, got.file != nil, test.wantFile)
+			}
+		})
+	}
+}
+
+func TestResolveUncachedFileFallbackDisabled(t *testing.T) {
+	resolver := newTestResolver(newTestAccount())
+	resolver.disableFileFallback = true
+
+	if _, err := resolver.resolveUncached(context.Background(), "op://Shared/Database/config.json", fileEncodingBase64); err == nil {
+		t.Error("resolveUncached succeeded, want the file not to be looked up with the fallback disabled")
+	}
+}
+
+func TestResolvePassesUnescapedReferences(t *testing.T) {
+	myVault, ciItem := testId("myvault"), testId("cicd")
+	lookup := &fakeLookup{
+		vaults: []onepassword.VaultOverview{{ID: myVault, Title: "My Vault"}},
+		items: map[string][]onepassword.Item{
+			myVault: {{ID: ciItem, Title: "CI / CD Secrets"}},
+		},
+		secrets: map[string]string{
+			"op://My Vault/Service/API Key":             "api-key",
+			"op://" + myVault + "/" + ciItem + "/token": "ci-token",
+		},
+	}
+	tests := []struct {
+		name         string
+		defaultVault string
+		reference    string
+		want         string
+	}{
+		{name: "literal spaces", reference: "op://My Vault/Service/API Key", want: "api-key"},
+		{name: "percent-encoded spaces", reference: "op://My%20Vault/Service/API%20Key", want: "api-key"},
+		{name: "default vault with space", defaultVault: "My Vault", reference: "op:///Service/API Key", want: "api-key"},
+		{name: "encoded slash in item title", reference: "op://My Vault/CI%20%2F%20CD%20Secrets/token", want: "ci-token"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := newTestResolver(lookup)
+			resolver.defaultVault = test.defaultVault
+
+			got, err := resolver.resolve(context.Background(), test.reference, fileEncodingBase64)
+			if err != nil {
+				t.Fatalf("resolve(%q) failed: %v", test.reference, err)
+			}
+			if got.value != test.want {
+				t.Errorf("resolve(%q) = %q, want %q", test.reference, got.value, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveUncachedFileFallbackIgnoresSdkErrorMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		sdkErr error
+	}{
+		{name: "current message", sdkErr: errors.New("error resolving secret reference: unable to retrieve file content, currently only text files are supported")},
+		{name: "reworded message", sdkErr: errors.New("error resolving secret reference: the file content could not be retrieved")},
+		{name: "unrelated message", sdkErr: errors.New("something went wrong")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lookup := newTestAccount()
+			lookup.resolveErr = test.sdkErr
+
+			got, err := newTestResolver(lookup).resolveUncached(context.Background(), "op://Shared/Database/TLS/cert", fileEncodingBase64)
+			if err != nil {
+				t.Fatalf("resolveUncached failed with the SDK error %q: %v", test.sdkErr, err)
+			}
+			if got.value != "Y2VydA==" || got.file == nil {
+				t.Errorf("resolveUncached = %q, want the file content Y2VydA==", got.value)
+			}
+		})
+	}
+}
+
+// newLargeTestAccount returns a lookup serving a vault with the given number of items, each with a file attachment,
+// delaying each call like a request to 1Password.
+func newLargeTestAccount(itemCount int) *fakeLookup {
+	vaultId := testId("large")
+	lookup := &fakeLookup{
+		vaults: []onepassword.VaultOverview{{ID: vaultId, Title: "Large"}},
+		items:  map[string][]onepassword.Item{},
+		files:  map[string][]byte{},
+		delay:  50 * time.Microsecond,
+	}
+	for i := range itemCount {
+		fileId := testId(fmt.Sprintf("file%03d", i))
+		lookup.items[vaultId] = append(lookup.items[vaultId], onepassword.Item{
+			ID:    testId(fmt.Sprintf("item%03d", i)),
+			Title: fmt.Sprintf("Service %d", i),
+			Files: []onepassword.ItemFile{{Attributes: onepassword.FileAttributes{ID: fileId, Name: "config.json", Size: 2}}},
+		})
+		lookup.files[fileId] = []byte("{}")
+	}
+	return lookup
+}
+
+// BenchmarkResolveFiles compares resolving file references one by one, listing the vaults and items for each reference,
+// with resolving them as a batch sharing the listings.
+func BenchmarkResolveFiles(b *testing.B) {
+	const itemCount, referenceCount = 300, 50
+	references := map[string]string{}
+	for i := range referenceCount {
+		references[fmt.Sprint(i)] = fmt.Sprintf("op://Large/Service %d/config.json", i*itemCount/referenceCount)
+	}
+
+	b.Run("one by one", func(b *testing.B) {
+		lookup := newLargeTestAccount(itemCount)
+		resolver := newTestResolver(lookup)
+		for range b.N {
+			for _, reference := range references {
+				if _, err := resolver.resolve(context.Background(), reference, fileEncodingBase64); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		b.ReportMetric(float64(lookup.callCount())/float64(b.N), "calls/op")
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		lookup := newLargeTestAccount(itemCount)
+		resolver := newTestResolver(lookup)
+		for range b.N {
+			if _, failed := resolver.resolveAll(context.Background(), references, fileEncodingBase64); len(failed) > 0 {
+				b.Fatal(failed)
+			}
+		}
+		b.ReportMetric(float64(lookup.callCount())/float64(b.N), "calls/op")
+	})
+}
+
+func TestResolveReferenceSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+		wantErr   bool
+	}{
+		{name: "2 segments resolve the primary value", reference: "op://Shared/Database", want: "secret-password"},
+		{name: "3 segments", reference: "op://Shared/Database/password", want: "secret-password"},
+		{name: "4 segments", reference: "op://Shared/Database/TLS/cert", want: "Y2VydA=="},
+		{name: "5 segments", reference: "op://Shared/Database/TLS/cert/extra", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newTestResolver(newTestAccount()).resolve(context.Background(), test.reference, fileEncodingBase64)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("resolve(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got.value != test.want {
+				t.Errorf("resolve(%q) = %q, want %q", test.reference, got.value, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveFileOfItemWithEncodedSlash(t *testing.T) {
+	lookup := newTestAccount()
+	shared := testId("shared")
+	lookup.items[shared] = append(lookup.items[shared], onepassword.Item{
+		ID:    testId("cicd"),
+		Title: "CI / CD Secrets",
+		Files: []onepassword.ItemFile{{Attributes: onepassword.FileAttributes{ID: testId("deploykey"), Name: "deploy.key", Size: 3}}},
+	})
+	lookup.files[testId("deploykey")] = []byte("key")
+
+	got, err := newTestResolver(lookup).resolve(context.Background(), "op://Shared/CI%20%2F%20CD%20Secrets/deploy.key", fileEncodingBase64)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if got.value != "a2V5" {
+		t.Errorf("resolve = %q, want the file content a2V5", got.value)
+	}
+}
+
+// vaultListingForbidden fails the test when listing vaults, to assert that vault IDs are used without looking them up.
+type vaultListingForbidden struct {
+	*fakeLookup
+	t *testing.T
+}
+
+func (l vaultListingForbidden) ListVaults(ctx context.Context) ([]onepassword.VaultOverview, error) {
+	l.t.Error("vaults were listed although the reference contains the vault ID")
+	return l.fakeLookup.ListVaults(ctx)
+}
+
+func TestResolveFileWithVaultId(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+	}{
+		{name: "vault id with item title", reference: "op://" + testId("shared") + "/Database/config.json", want: "eyJkZWJ1ZyI6dHJ1ZX0="},
+		{name: "vault id with item id", reference: "op://" + testId("shared") + "/" + testId("database") + "/TLS/cert", want: "Y2VydA=="},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := newTestResolver(vaultListingForbidden{fakeLookup: newTestAccount(), t: t})
+
+			got, err := resolver.resolve(context.Background(), test.reference, fileEncodingBase64)
+			if err != nil {
+				t.Fatalf("resolve(%q) failed: %v", test.reference, err)
+			}
+			if got.value != test.want {
+				t.Errorf("resolve(%q) = %q, want %q", test.reference, got.value, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveAttribute(t *testing.T) {
+	code := "123456"
+	totpField := onepassword.ItemField{
+		ID:        "TOTP_abc",
+		Title:     "one-time password",
+		FieldType: onepassword.ItemFieldTypeTOTP,
+		Value:     "otpauth://totp/test?secret=JBSWY3DPEHPK3PXP",
+		Details:   ptrTo(onepassword.NewItemFieldDetailsTypeVariantOTP(&onepassword.OTPFieldDetails{Code: &code})),
+	}
+	passwordField := onepassword.ItemField{ID: "password", Title: "password", FieldType: onepassword.ItemFieldTypeConcealed, Value: "secret-password"}
+
+	t.Run("totp of one-time password field", func(t *testing.T) {
+		got, err := fieldAttribute(totpField, fieldAttributeTotp)
+		if err != nil || got != code {
+			t.Errorf("fieldAttribute(totp) = %q, %v, want %q", got, err, code)
+		}
+	})
+	t.Run("totp of password field", func(t *testing.T) {
+		if got, err := fieldAttribute(passwordField, fieldAttributeTotp); err == nil {
+			t.Errorf("fieldAttribute(totp) = %q, want an error for a field without one-time password", got)
+		}
+	})
+	t.Run("totp query is passed to 1Password", func(t *testing.T) {
+		lookup := newTestAccount()
+		lookup.secrets["op://Shared/Login/one-time password?attribute=totp"] = code
+
+		got, err := newTestResolver(lookup).resolve(context.Background(), "op://Shared/Login/one-time%20password?attribute=totp", fileEncodingBase64)
+		if err != nil || got.value != code {
+			t.Errorf("resolve = %q, %v, want %q", got.value, err, code)
+		}
+	})
+	t.Run("password attribute is rejected without calling 1Password", func(t *testing.T) {
+		lookup := newTestAccount()
+
+		_, err := newTestResolver(lookup).resolve(context.Background(), "op://Shared/Database/password?attribute=password", fileEncodingBase64)
+		if err == nil || !strings.Contains(err.Error(), "unsupported attribute 'password'") {
+			t.Errorf("resolve error = %v, want the unsupported attribute to be reported", err)
+		}
+		if calls := lookup.callCount(); calls != 0 {
+			t.Errorf("resolve made %d calls, want none", calls)
+		}
+	})
+}
+
+func TestResolveBareReference(t *testing.T) {
+	for _, reference := range []string{"op://Shared/Database/password", "Shared/Database/password"} {
+		got, err := newTestResolver(newTestAccount()).resolve(context.Background(), reference, fileEncodingBase64)
+		if err != nil {
+			t.Fatalf("resolve(%q) failed: %v", reference, err)
+		}
+		if got.value != "secret-password" {
+			t.Errorf("resolve(%q) = %q, want %q", reference, got.value, "secret-password")
+		}
+	}
+}
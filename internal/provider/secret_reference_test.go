@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseSecretReferenceSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      secretReference
+		wantErr   bool
+	}{
+		{name: "2 segments", reference: "op://vault/item", want: secretReference{vault: "vault", item: "item"}},
+		{name: "3 segments", reference: "op://vault/item/field", want: secretReference{vault: "vault", item: "item", field: "field"}},
+		{name: "4 segments", reference: "op://vault/item/section/field", want: secretReference{vault: "vault", item: "item", section: "section", field: "field"}},
+		{name: "3 segments with empty vault", reference: "op:///item/field", want: secretReference{item: "item", field: "field"}},
+		{name: "1 segment", reference: "op://vault", wantErr: true},
+		{name: "5 segments", reference: "op://vault/item/section/field/extra", wantErr: true},
+		{name: "empty item", reference: "op://vault//field", wantErr: true},
+		{name: "empty field", reference: "op://vault/item/section/", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseSecretReference(test.reference)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSecretReference(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("parseSecretReference(%q) = %+v, want %+v", test.reference, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseSecretReferenceEncodedSeparators(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      secretReference
+		wantErr   bool
+	}{
+		{name: "encoded slash", reference: "op://Shared/CI%20%2F%20CD%20Secrets/token", want: secretReference{vault: "Shared", item: "CI / CD Secrets", field: "token"}},
+		{name: "lower case encoded slash", reference: "op://Shared/CI%2fCD/token", want: secretReference{vault: "Shared", item: "CI/CD", field: "token"}},
+		{name: "encoded spaces", reference: "op://My%20Vault/My%20Item/API%20Key", want: secretReference{vault: "My Vault", item: "My Item", field: "API Key"}},
+		{name: "literal spaces", reference: "op://My Vault/My Item/API Key", want: secretReference{vault: "My Vault", item: "My Item", field: "API Key"}},
+		{name: "encoded slash in section", reference: "op://Shared/Item/TLS%2FSSL/cert", want: secretReference{vault: "Shared", item: "Item", section: "TLS/SSL", field: "cert"}},
+		{name: "invalid encoding", reference: "op://Shared/100%/token", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseSecretReference(test.reference)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSecretReference(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("parseSecretReference(%q) = %+v, want %+v", test.reference, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSecretReferenceStringRoundTrip(t *testing.T) {
+	references := []secretReference{
+		{vault: "Shared", item: "CI / CD Secrets", field: "token"},
+		{vault: "My Vault", item: "100% Item", section: "TLS/SSL", field: "cert.pem"},
+		{vault: "Shared", item: "Login", field: "one-time password", attribute: fieldAttributeOtp},
+	}
+	for _, reference := range references {
+		parsed, err := parseSecretReference(reference.String())
+		if err != nil {
+			t.Fatalf("parseSecretReference(%q) failed: %v", reference.String(), err)
+		}
+		if parsed != reference {
+			t.Errorf("parseSecretReference(%q) = %+v, want %+v", reference.String(), parsed, reference)
+		}
+	}
+}
+
+func TestParseSecretReferenceAttribute(t *testing.T) {
+	tests := []struct {
+		name          string
+		reference     string
+		wantAttribute string
+		wantErr       bool
+	}{
+		{name: "totp", reference: "op://vault/item/one-time password?attribute=totp", wantAttribute: fieldAttributeTotp},
+		{name: "totp in upper case", reference: "op://vault/item/one-time password?attribute=TOTP", wantAttribute: fieldAttributeTotp},
+		{name: "otp", reference: "op://vault/item/one-time password?attribute=otp", wantAttribute: fieldAttributeOtp},
+		{name: "type", reference: "op://vault/item/password?attribute=type", wantAttribute: fieldAttributeType},
+		{name: "no attribute", reference: "op://vault/item/password"},
+		// password is a field of login items, not an attribute of fields
+		{name: "password", reference: "op://vault/item/password?attribute=password", wantErr: true},
+		{name: "repeated attribute", reference: "op://vault/item/password?attribute=type&attribute=id", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseSecretReference(test.reference)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSecretReference(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got.attribute != test.wantAttribute {
+				t.Errorf("parseSecretReference(%q) attribute = %q, want %q", test.reference, got.attribute, test.wantAttribute)
+			}
+		})
+	}
+}
+
+func TestParseSecretReferencePrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      secretReference
+		wantErr   bool
+	}{
+		{name: "prefixed", reference: "op://vault/item/field", want: secretReference{vault: "vault", item: "item", field: "field"}},
+		{name: "bare", reference: "vault/item/field", want: secretReference{vault: "vault", item: "item", field: "field"}},
+		{name: "bare with section", reference: "vault/item/section/field", want: secretReference{vault: "vault", item: "item", section: "section", field: "field"}},
+		{name: "prefixed with whitespace", reference: " op://vault/item/field\n", want: secretReference{vault: "vault", item: "item", field: "field"}},
+		{name: "bare with whitespace", reference: " vault/item/field\n", want: secretReference{vault: "vault", item: "item", field: "field"}},
+		{name: "other scheme", reference: "https://vault/item/field", wantErr: true},
+		{name: "upper case scheme", reference: "OP://vault/item/field", wantErr: true},
+		{name: "scheme with single slash", reference: "op:/vault/item/field", wantErr: true},
+		{name: "scheme without slashes", reference: "op:vault/item/field", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseSecretReference(test.reference)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSecretReference(%q) error = %v, want error %v", test.reference, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("parseSecretReference(%q) = %+v, want %+v", test.reference, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = secretReferenceValidator{}
+
+// secretReferenceValidator validates that a string is a well-formed 1Password secret reference,
+// so malformed references are reported at plan time without calling 1Password.
+// Names of reference aliases are accepted as well, as the aliases of the provider are only known when resolving them.
+type secretReferenceValidator struct {
+	// rejectAliases only accepts secret references, e.g. for the targets of aliases.
+	rejectAliases bool
+}
+
+func (v secretReferenceValidator) Description(_ context.Context) string {
+	description := "value must be a secret reference of the form op://vault/item/field or op://vault/item/section/field, the op:// scheme may be omitted"
+	if v.rejectAliases {
+		return description
+	}
+	return description + ", or the name of an alias configured in the provider"
+}
+
+func (v secretReferenceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v secretReferenceValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.rejectAliases && isAliasName(req.ConfigValue.ValueString()) {
+		return
+	}
+	if _, err := parseSecretReference(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Secret Reference",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretReferenceValidator(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         types.String
+		rejectAliases bool
+		wantErr       bool
+	}{
+		{name: "field reference", value: types.StringValue("op://vault/item/field")},
+		{name: "section reference", value: types.StringValue("op://vault/item/section/field")},
+		{name: "file reference", value: types.StringValue("op://vault/item/config.json")},
+		{name: "file in section", value: types.StringValue("op://vault/item/files/cert.pem")},
+		{name: "primary value", value: types.StringValue("op://vault/item")},
+		{name: "default vault", value: types.StringValue("op:///item/field")},
+		{name: "attribute", value: types.StringValue("op://vault/item/field?attribute=otp")},
+		{name: "bare reference", value: types.StringValue("vault/item/field")},
+		{name: "alias", value: types.StringValue("db_password")},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "alias rejected", value: types.StringValue("db_password"), rejectAliases: true, wantErr: true},
+		{name: "wrong scheme", value: types.StringValue("http://vault/item/field"), wantErr: true},
+		{name: "misspelled scheme", value: types.StringValue("op:/vault/item/field"), wantErr: true},
+		{name: "too few segments", value: types.StringValue("op://vault"), wantErr: true},
+		{name: "too many segments", value: types.StringValue("op://vault/item/section/field/extra"), wantErr: true},
+		{name: "empty segment", value: types.StringValue("op://vault//field"), wantErr: true},
+		{name: "invalid encoding", value: types.StringValue("op://vault/item/100%"), wantErr: true},
+		{name: "unsupported query", value: types.StringValue("op://vault/item/field?format=json"), wantErr: true},
+		{name: "empty query", value: types.StringValue("op://vault/item/field?"), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("id"), ConfigValue: test.value}
+			resp := &validator.StringResponse{}
+
+			secretReferenceValidator{rejectAliases: test.rejectAliases}.ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("validating %s reported errors %v, want error %v", test.value, resp.Diagnostics, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &secretReferencesDataSource{}
+	_ datasource.DataSourceWithConfigure = &secretReferencesDataSource{}
+)
+
+func NewSecretReferencesDataSource() datasource.DataSource {
+	return &secretReferencesDataSource{}
+}
+
+type secretReferencesDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type secretReferencesDataSourceModel struct {
+	References map[string]string `tfsdk:"references"`
+	Encoding   types.String      `tfsdk:"encoding"`
+	Account    types.String      `tfsdk:"account"`
+	Trim       types.Bool        `tfsdk:"trim"`
+	Values     types.Map         `tfsdk:"values"`
+}
+
+func (d *secretReferencesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *secretReferencesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_references"
+}
+
+func (d *secretReferencesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves multiple 1Password secret references at once.",
+		Attributes: map[string]schema.Attribute{
+			"references": schema.MapAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The 1Password secret references to resolve, keyed by an arbitrary name.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(secretReferenceValidator{}),
+				},
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"trim": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove leading and trailing whitespace like spaces, tabs and newlines from the content of file attachments before encoding it. Defaults to `false`.<br>Has no effect on references to fields.",
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of file attachment contents, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise. Has no effect on references to fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"values": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The resolved secret values, keyed like the given references.",
+			},
+		},
+	}
+}
+
+func (d *secretReferencesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state secretReferencesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := d.resolver.forAccount(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	// bound all calls of this read together, on top of the timeout of each call
+	readCtx, cancel := resolver.withReadTimeout(ctx)
+	defer cancel()
+
+	resolved, failed := resolver.resolveAll(readCtx, state.References, state.Encoding.ValueString())
+	if len(failed) > 0 {
+		// report the failed references in a stable order
+		failedKeys := make([]string, 0, len(failed))
+		for key := range failed {
+			failedKeys = append(failedKeys, key)
+		}
+		sort.Strings(failedKeys)
+		if len(failedKeys) > 1 {
+			resp.Diagnostics.AddError(
+				"Unable to read secret references",
+				fmt.Sprintf("%d of %d secret references could not be resolved, with the keys: %s", len(failedKeys), len(state.References), strings.Join(failedKeys, ", ")),
+			)
+		}
+		for _, key := range failedKeys {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("references").AtMapKey(key),
+				readErrorSummary(failed[key]),
+				fmt.Sprintf("Resolving the secret reference with key '%s' failed: %s", key, failed[key].Error()),
+			)
+		}
+		return
+	}
+
+	values := make(map[string]attr.Value, len(resolved))
+	for key, secret := range resolved {
+		if state.Trim.ValueBool() {
+			secret = secret.trimmed(state.Encoding.ValueString())
+		}
+		resolver.checkEmptyValue(&resp.Diagnostics, path.Root("references").AtMapKey(key), state.References[key], secret)
+		values[key] = types.StringValue(secret.value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resolvedValues, diags := types.MapValue(types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Values = resolvedValues
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sshKeyDataSource{}
+	_ datasource.DataSourceWithConfigure = &sshKeyDataSource{}
+)
+
+// Supported formats of SSH private keys.
+const (
+	privateKeyFormatOpenSSH = "openssh"
+	privateKeyFormatPKCS8   = "pkcs8"
+)
+
+func NewSshKeyDataSource() datasource.DataSource {
+	return &sshKeyDataSource{}
+}
+
+type sshKeyDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type sshKeyDataSourceModel struct {
+	Vault            types.String `tfsdk:"vault"`
+	Item             types.String `tfsdk:"item"`
+	Field            types.String `tfsdk:"field"`
+	PrivateKeyFormat types.String `tfsdk:"private_key_format"`
+	PrivateKey       types.String `tfsdk:"private_key"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	Fingerprint      types.String `tfsdk:"fingerprint"`
+	KeyType          types.String `tfsdk:"key_type"`
+}
+
+func (d *sshKeyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *sshKeyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (d *sshKeyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the private key, public key and fingerprint of an SSH key field.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"field": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The label of the SSH key field.<br>If omitted, the first SSH key field of the item is used.",
+			},
+			"private_key_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The format of the private key, either `openssh` or `pkcs8`. Defaults to `openssh`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(privateKeyFormatOpenSSH, privateKeyFormatPKCS8),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The PEM encoded private key in the requested format.",
+			},
+			"public_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public key in OpenSSH authorized keys format.",
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 fingerprint of the key.",
+			},
+			"key_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the key, e.g. `Ed25519` or `RSA, 4096-bit`.",
+			},
+		},
+	}
+}
+
+func (d *sshKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sshKeyDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	field, err := getSshKeyField(item, state.Field.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read SSH key",
+			err.Error(),
+		)
+		return
+	}
+
+	privateKey, err := formatPrivateKey(field.Value, state.PrivateKeyFormat.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read SSH key",
+			fmt.Sprintf("The private key of field '%s' cannot be converted: %s", field.Title, err.Error()),
+		)
+		return
+	}
+
+	state.PrivateKey = types.StringValue(privateKey)
+	state.PublicKey = types.StringNull()
+	state.Fingerprint = types.StringNull()
+	state.KeyType = types.StringNull()
+	if field.Details != nil && field.Details.SSHKey() != nil {
+		attributes := field.Details.SSHKey()
+		state.PublicKey = types.StringValue(attributes.PublicKey)
+		state.Fingerprint = types.StringValue(attributes.Fingerprint)
+		state.KeyType = types.StringValue(attributes.KeyType)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// searches the SSH key fields of the given item, matching by given field label if not empty
+// returns the field and nil on match, an empty field and an error object otherwise.
+func getSshKeyField(item onepassword.Item, fieldLabel string) (onepassword.ItemField, error) {
+	for _, field := range item.Fields {
+		if field.FieldType == onepassword.ItemFieldTypeSSHKey && (fieldLabel == "" || field.Title == fieldLabel) {
+			return field, nil
+		}
+	}
+	if fieldLabel != "" {
+		return onepassword.ItemField{}, fmt.Errorf("SSH key field '%s' not found in item '%s'", fieldLabel, item.Title)
+	}
+	return onepassword.ItemField{}, fmt.Errorf("item '%s' has no SSH key field", item.Title)
+}
+
+// converts the given PEM encoded private key into the given format, defaulting to the OpenSSH format.
+func formatPrivateKey(privateKey string, format string) (string, error) {
+	key, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+	if err != nil {
+		return "", err
+	}
+	// keys parsed from the OpenSSH format are returned as pointer, which is not supported by x509
+	if ed25519Key, ok := key.(*ed25519.PrivateKey); ok {
+		key = *ed25519Key
+	}
+
+	var block *pem.Block
+	if format == privateKeyFormatPKCS8 {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	} else {
+		block, err = ssh.MarshalPrivateKey(key, "")
+		if err != nil {
+			return "", err
+		}
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &sshKeyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &sshKeyEphemeralResource{}
+)
+
+func NewSshKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &sshKeyEphemeralResource{}
+}
+
+type sshKeyEphemeralResource struct {
+	resolver *secretReferenceResolver
+}
+
+type sshKeyEphemeralResourceModel struct {
+	Vault            types.String `tfsdk:"vault"`
+	Item             types.String `tfsdk:"item"`
+	Field            types.String `tfsdk:"field"`
+	Account          types.String `tfsdk:"account"`
+	PrivateKeyFormat types.String `tfsdk:"private_key_format"`
+	PrivateKey       types.String `tfsdk:"private_key"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	Fingerprint      types.String `tfsdk:"fingerprint"`
+	KeyType          types.String `tfsdk:"key_type"`
+}
+
+func (e *sshKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.resolver = resolver
+}
+
+func (e *sshKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (e *sshKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the private key, public key and fingerprint of an SSH key field on every run without persisting them in the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"field": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The label of the SSH key field.<br>If omitted, the first SSH key field of the item is used.",
+			},
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to use. Defaults to the account configured directly in the provider.",
+			},
+			"private_key_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The format of the private key, either `openssh` or `pkcs8`. Defaults to `openssh`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(privateKeyFormatOpenSSH, privateKeyFormatPKCS8),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The PEM encoded private key in the requested format.",
+			},
+			"public_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public key in OpenSSH authorized keys format.",
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 fingerprint of the key.",
+			},
+			"key_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the key, e.g. `Ed25519` or `RSA, 4096-bit`.",
+			},
+		},
+	}
+}
+
+func (e *sshKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var result sshKeyEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := e.resolver.forAccount(result.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	item, err := resolver.getItem(ctx, result.Vault.ValueString(), result.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	field, err := getSshKeyField(item, result.Field.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read SSH key",
+			err.Error(),
+		)
+		return
+	}
+
+	privateKey, err := formatPrivateKey(field.Value, result.PrivateKeyFormat.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read SSH key",
+			fmt.Sprintf("The private key of field '%s' cannot be converted: %s", field.Title, err.Error()),
+		)
+		return
+	}
+
+	result.PrivateKey = types.StringValue(privateKey)
+	result.PublicKey = types.StringNull()
+	result.Fingerprint = types.StringNull()
+	result.KeyType = types.StringNull()
+	if field.Details != nil && field.Details.SSHKey() != nil {
+		attributes := field.Details.SSHKey()
+		result.PublicKey = types.StringValue(attributes.PublicKey)
+		result.Fingerprint = types.StringValue(attributes.Fingerprint)
+		result.KeyType = types.StringValue(attributes.KeyType)
+	}
+
+	// Set result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
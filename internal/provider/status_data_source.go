@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &statusDataSource{}
+	_ datasource.DataSourceWithConfigure = &statusDataSource{}
+)
+
+// Backends the provider talks to.
+const (
+	backendServiceAccount = "service_account"
+	backendConnect        = "connect"
+	backendCli            = "cli"
+)
+
+func NewStatusDataSource() datasource.DataSource {
+	return &statusDataSource{}
+}
+
+type statusDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type statusDataSourceModel struct {
+	Account    types.String `tfsdk:"account"`
+	Ok         types.Bool   `tfsdk:"ok"`
+	Backend    types.String `tfsdk:"backend"`
+	VaultCount types.Int64  `tfsdk:"vault_count"`
+	Detail     types.String `tfsdk:"detail"`
+}
+
+func (d *statusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *statusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_status"
+}
+
+func (d *statusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that the provider can authenticate with 1Password by listing the accessible vaults, without reading any secrets.<br>" +
+			"Failed checks do not fail the data source, so they can be reported by checks or postconditions, e.g. as pre-flight check of a pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the account of the provider `accounts` to check. Defaults to the account configured directly in the provider.",
+			},
+			"ok": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the provider could authenticate and list the accessible vaults.",
+			},
+			"backend": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The backend the provider talks to, `" + backendServiceAccount + "`, `" + backendConnect + "` or `" + backendCli + "`.",
+			},
+			"vault_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of vaults accessible by the token, only set if the check succeeded.",
+			},
+			"detail": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The reason why the check failed, only set if it failed.",
+			},
+		},
+	}
+}
+
+func (d *statusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state statusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver, err := d.resolver.forAccount(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account"),
+			"Unknown Account",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Backend = types.StringValue(backendServiceAccount)
+	switch resolver.client.SecretsAPI.(type) {
+	case *connectSecrets:
+		state.Backend = types.StringValue(backendConnect)
+	case *cliSecrets:
+		state.Backend = types.StringValue(backendCli)
+	}
+
+	vaults, err := resolver.listVaults(ctx)
+	if err != nil {
+		state.Ok = types.BoolValue(false)
+		state.VaultCount = types.Int64Null()
+		state.Detail = types.StringValue(err.Error())
+	} else {
+		state.Ok = types.BoolValue(true)
+		state.VaultCount = types.Int64Value(int64(len(vaults)))
+		state.Detail = types.StringNull()
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &taggedItemDataSource{}
+	_ datasource.DataSourceWithConfigure = &taggedItemDataSource{}
+)
+
+func NewTaggedItemDataSource() datasource.DataSource {
+	return &taggedItemDataSource{}
+}
+
+type taggedItemDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type taggedItemDataSourceModel struct {
+	Vault     types.String `tfsdk:"vault"`
+	Tag       types.String `tfsdk:"tag"`
+	Field     types.String `tfsdk:"field"`
+	Encoding  types.String `tfsdk:"encoding"`
+	ItemID    types.String `tfsdk:"item_id"`
+	Title     types.String `tfsdk:"title"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	Reference types.String `tfsdk:"reference"`
+	Value     types.String `tfsdk:"value"`
+}
+
+func (d *taggedItemDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *taggedItemDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tagged_item"
+}
+
+func (d *taggedItemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a field of the most recently updated item carrying a tag, e.g. the currently active item of a rotated secret.<br>" +
+			"Decouples the configuration from the titles of the items, which may change on every rotation.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the items.",
+			},
+			"tag": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The tag the item must carry, e.g. `active`.",
+			},
+			"field": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The label or ID of the field or file attachment to read.<br>If omitted, the primary value of the item is read, like for secret references in the form `op://vault/item`.",
+			},
+			"encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The encoding of file attachment contents, one of `base64`, `base64url`, `raw` or `auto`. Defaults to `base64`.<br>`base64url` uses the URL-safe alphabet without padding, e.g. for JWTs. `auto` uses the raw content for UTF-8 text files and base64 otherwise. Has no effect on fields.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileEncodingBase64, fileEncodingBase64Url, fileEncodingRaw, fileEncodingAuto),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the selected item.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The title of the selected item.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the selected item was last updated, in RFC 3339 format.",
+			},
+			"reference": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The secret reference of the read value using the IDs of the vault and the selected item.",
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The resolved secret value.",
+			},
+		},
+	}
+}
+
+func (d *taggedItemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state taggedItemDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaultId, err := d.resolver.getVaultId(ctx, state.Vault.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read vault",
+			err.Error(),
+		)
+		return
+	}
+
+	items, err := d.resolver.listItems(ctx, vaultId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list items",
+			err.Error(),
+		)
+		return
+	}
+
+	item, found := newestTaggedItem(items, state.Tag.ValueString())
+	if !found {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			"No Tagged Item Found",
+			fmt.Sprintf("No item of vault '%s' carries the tag '%s'.", state.Vault.ValueString(), state.Tag.ValueString()),
+		)
+		return
+	}
+
+	reference := secretReference{vault: vaultId, item: item.ID, field: state.Field.ValueString()}.String()
+	resolved, err := d.resolver.resolve(ctx, reference, state.Encoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read secret reference",
+			fmt.Sprintf("Reading the item '%s' carrying the tag '%s' failed: %s", item.Title, state.Tag.ValueString(), err.Error()),
+		)
+		return
+	}
+	d.resolver.checkEmptyValue(&resp.Diagnostics, path.Root("field"), reference, resolved)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ItemID = types.StringValue(item.ID)
+	state.Title = types.StringValue(item.Title)
+	state.UpdatedAt = types.StringValue(item.UpdatedAt.Format(time.RFC3339))
+	state.Reference = types.StringValue(reference)
+	state.Value = types.StringValue(resolved.value)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// searches the given items for the most recently updated active item carrying the given tag
+// returns the item and true on match, an empty item and false otherwise.
+func newestTaggedItem(items []onepassword.ItemOverview, tag string) (onepassword.ItemOverview, bool) {
+	var newest onepassword.ItemOverview
+	found := false
+	for _, item := range items {
+		if item.State == onepassword.ItemStateArchived || !slices.Contains(item.Tags, tag) {
+			continue
+		}
+		if !found || item.UpdatedAt.After(newest.UpdatedAt) {
+			newest, found = item, true
+		}
+	}
+	return newest, found
+}
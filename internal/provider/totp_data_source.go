@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &totpDataSource{}
+	_ datasource.DataSourceWithConfigure = &totpDataSource{}
+)
+
+func NewTotpDataSource() datasource.DataSource {
+	return &totpDataSource{}
+}
+
+type totpDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type totpDataSourceModel struct {
+	Vault types.String `tfsdk:"vault"`
+	Item  types.String `tfsdk:"item"`
+	Field types.String `tfsdk:"field"`
+	Code  types.String `tfsdk:"code"`
+}
+
+func (d *totpDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *totpDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_totp"
+}
+
+func (d *totpDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the current code of a one-time password field.<br>" +
+			"One-time password codes change every 30 seconds, so the code is computed anew on every read and never reused from a previous run.",
+		Attributes: map[string]schema.Attribute{
+			"vault": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the vault containing the item.",
+			},
+			"item": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title or ID of the item.",
+			},
+			"field": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The label of the one-time password field.<br>If omitted, the first one-time password field of the item is used.",
+			},
+			"code": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The one-time password code valid at the time of the read.",
+			},
+		},
+	}
+}
+
+func (d *totpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state totpDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := d.resolver.getItem(ctx, state.Vault.ValueString(), state.Item.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read item",
+			err.Error(),
+		)
+		return
+	}
+
+	code, err := getTotpCode(item, state.Field.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read one-time password",
+			err.Error(),
+		)
+		return
+	}
+	state.Code = types.StringValue(code)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// searches the one-time password fields of the given item, matching by given field label if not empty
+// returns the current code and nil on match, empty string and an error object otherwise.
+func getTotpCode(item onepassword.Item, fieldLabel string) (string, error) {
+	for _, field := range item.Fields {
+		if field.FieldType != onepassword.ItemFieldTypeTOTP || (fieldLabel != "" && field.Title != fieldLabel) {
+			continue
+		}
+		return totpCode(field)
+	}
+	if fieldLabel != "" {
+		return "", fmt.Errorf("one-time password field '%s' not found in item '%s'", fieldLabel, item.Title)
+	}
+	return "", fmt.Errorf("item '%s' has no one-time password field", item.Title)
+}
+
+// returns the current code of the given one-time password field and nil, or empty string and an error object if no code is available.
+func totpCode(field onepassword.ItemField) (string, error) {
+	if field.FieldType != onepassword.ItemFieldTypeTOTP {
+		return "", fmt.Errorf("field '%s' is not a one-time password field but of type %s", field.Title, field.FieldType)
+	}
+	if field.Details == nil || field.Details.OTP() == nil {
+		return "", fmt.Errorf("field '%s' contains no one-time password details", field.Title)
+	}
+	otp := field.Details.OTP()
+	if otp.ErrorMessage != nil {
+		return "", fmt.Errorf("unable to generate one-time password for field '%s': %s", field.Title, *otp.ErrorMessage)
+	}
+	if otp.Code == nil {
+		return "", fmt.Errorf("field '%s' contains no one-time password code", field.Title)
+	}
+	return *otp.Code, nil
+}
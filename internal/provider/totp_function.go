@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &totpFunction{}
+
+func NewTotpFunction(provider *OPSecretReferenceProvider) function.Function {
+	return &totpFunction{provider: provider}
+}
+
+type totpFunction struct {
+	provider *OPSecretReferenceProvider
+}
+
+func (f *totpFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "totp"
+}
+
+func (f *totpFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the current code of a one-time password field",
+		MarkdownDescription: "Returns the code of the one-time password field the given 1Password secret reference points to, valid at the time of the call.<br>" +
+			"References in the form `op://vault/item` without a field use the first one-time password field of the item. " +
+			"Fails if the referenced field is not a one-time password field.<br>" +
+			"If the provider has not been configured yet, the OP_CONNECT_HOST and OP_CONNECT_TOKEN or the OP_SERVICE_ACCOUNT_TOKEN environment variables are used to authenticate.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "reference",
+				MarkdownDescription: "The 1Password secret reference of the one-time password field.<br>See https://developer.1password.com/docs/cli/secret-reference-syntax/ for details.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *totpFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secretReference string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secretReference))
+	if resp.Error != nil {
+		return
+	}
+
+	resolver, err := f.provider.functionResolver(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to create onepassword client: " + err.Error())
+		return
+	}
+
+	reference, _, err := resolver.parseReference(secretReference)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid secret reference: "+err.Error())
+		return
+	}
+
+	item, err := resolver.getItem(ctx, reference.vault, reference.item)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to read item: "+err.Error())
+		return
+	}
+
+	var code string
+	if reference.field == "" {
+		code, err = getTotpCode(item, "")
+	} else {
+		field, fieldErr := getField(item, reference.section, reference.field)
+		if fieldErr != nil {
+			resp.Error = function.NewArgumentFuncError(0, "Unable to read field: "+fieldErr.Error())
+			return
+		}
+		code, err = totpCode(field)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to read one-time password: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, code))
+}
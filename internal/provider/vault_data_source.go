@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/1password/onepassword-sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vaultDataSource{}
+	_ datasource.DataSourceWithConfigure = &vaultDataSource{}
+)
+
+func NewVaultDataSource() datasource.DataSource {
+	return &vaultDataSource{}
+}
+
+type vaultDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type vaultDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Title     types.String `tfsdk:"title"`
+	ItemCount types.Int64  `tfsdk:"item_count"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (d *vaultDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *vaultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault"
+}
+
+func (d *vaultDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single vault the service account has access to, either by its title or by its ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the vault. Exactly one of `id` and `title` must be given.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("title")),
+				},
+			},
+			"title": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The title of the vault. Exactly one of `id` and `title` must be given.<br>Fails if the title matches multiple vaults.",
+			},
+			"item_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of items in the vault.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the vault was created, in RFC 3339 format.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the vault was last updated, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (d *vaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state vaultDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vault, err := d.getVault(ctx, state.ID.ValueString(), state.Title.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read vault",
+			err.Error(),
+		)
+		return
+	}
+
+	items, err := d.resolver.listItems(ctx, vault.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list items",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(vault.ID)
+	state.Title = types.StringValue(vault.Title)
+	state.ItemCount = types.Int64Value(int64(len(items)))
+	state.CreatedAt = types.StringValue(vault.CreatedAt.Format(time.RFC3339))
+	state.UpdatedAt = types.StringValue(vault.UpdatedAt.Format(time.RFC3339))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// searches all available vaults for the vault with the given ID, or matching the given title if no ID is given
+// returns the vault and nil on a unique match, an empty vault and an error object otherwise.
+func (d *vaultDataSource) getVault(ctx context.Context, vaultId string, title string) (onepassword.VaultOverview, error) {
+	if vaultId == "" {
+		var err error
+		if vaultId, err = d.resolver.getVaultId(ctx, title); err != nil {
+			return onepassword.VaultOverview{}, err
+		}
+	}
+
+	vaults, err := d.resolver.listVaults(ctx)
+	if err != nil {
+		return onepassword.VaultOverview{}, err
+	}
+	for _, vault := range vaults {
+		if vault.ID == vaultId {
+			return vault, nil
+		}
+	}
+	return onepassword.VaultOverview{}, fmt.Errorf(
+		"%w: '%s'. The service account or Connect token may not have been granted access to it, accessible vaults are: %s",
+		errVaultNotFound, vaultId, vaultTitles(vaults),
+	)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vaultsDataSource{}
+	_ datasource.DataSourceWithConfigure = &vaultsDataSource{}
+)
+
+func NewVaultsDataSource() datasource.DataSource {
+	return &vaultsDataSource{}
+}
+
+type vaultsDataSource struct {
+	resolver *secretReferenceResolver
+}
+
+type vaultsDataSourceModel struct {
+	Vaults []vaultModel `tfsdk:"vaults"`
+}
+
+type vaultModel struct {
+	ID    types.String `tfsdk:"id"`
+	Title types.String `tfsdk:"title"`
+}
+
+func (d *vaultsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resolver, ok := req.ProviderData.(*secretReferenceResolver)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *secretReferenceResolver, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.resolver = resolver
+}
+
+func (d *vaultsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vaults"
+}
+
+func (d *vaultsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all vaults the service account has access to.",
+		Attributes: map[string]schema.Attribute{
+			"vaults": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The vaults available to the service account.<br>Empty if no vaults are accessible.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the vault.",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The title of the vault.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vaultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state vaultsDataSourceModel
+
+	vaults, err := d.resolver.listVaults(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list vaults",
+			err.Error(),
+		)
+		return
+	}
+
+	// always return a list, even if no vaults are visible to the service account
+	state.Vaults = []vaultModel{}
+	for _, vault := range vaults {
+		state.Vaults = append(state.Vaults, vaultModel{
+			ID:    types.StringValue(vault.ID),
+			Title: types.StringValue(vault.Title),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}